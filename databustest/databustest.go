@@ -0,0 +1,77 @@
+// Package databustest starts an e2cdatabus server in memory,
+// so that excel2config integrations can be unit tested without mysql, redis or a tcp port.
+package databustest
+
+import (
+	"context"
+	"github.com/fandypeng/e2cdatabus/auth"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"github.com/fandypeng/e2cdatabus/rpcserver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+)
+
+const (
+	AppKey    = "MdQ4tR8uWz1xKc6B"
+	AppSecret = "Sk2bQ9vLx7TqM3nW8cZr4HfJ6pYd1Ga5"
+
+	bufSize = 1024 * 1024
+)
+
+// Server is a databus server listening on an in-memory connection
+type Server struct {
+	// Client is connected to the server and authenticated with AppKey and AppSecret
+	Client  pb.DatabusClient
+	Service *rpcserver.Service
+	Storage *rpcserver.MemoryStorage
+
+	server *grpc.Server
+	conn   *grpc.ClientConn
+}
+
+// NewServer starts a server with a MemoryStorage and a MemoryHistoryStore and returns it
+// with a ready client, handlers are installed after the auth interceptor like rpcserver.Start does
+func NewServer(handlers ...grpc.UnaryServerInterceptor) (*Server, error) {
+	storage := rpcserver.NewMemoryStorage()
+	service := rpcserver.NewService()
+	service.SetStorage(storage)
+	service.SetHistoryStore(rpcserver.NewMemoryHistoryStore())
+	s, err := NewServerWithService(service, handlers...)
+	if err != nil {
+		return nil, err
+	}
+	s.Storage = storage
+	return s, nil
+}
+
+// NewServerWithService starts a server with the given service and returns it with a ready client
+func NewServerWithService(service *rpcserver.Service, handlers ...grpc.UnaryServerInterceptor) (*Server, error) {
+	gs, err := rpcserver.NewServer(rpcserver.Conf{AppKey: AppKey, AppSecret: AppSecret}, service, handlers...)
+	if err != nil {
+		return nil, err
+	}
+	lis := bufconn.Listen(bufSize)
+	go gs.Serve(lis)
+	dialer := func(ctx context.Context, addr string) (net.Conn, error) {
+		return lis.Dial()
+	}
+	conn, err := grpc.Dial("bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(auth.New(AppKey, AppSecret)))
+	if err != nil {
+		gs.Stop()
+		return nil, err
+	}
+	return &Server{
+		Client:  pb.NewDatabusClient(conn),
+		Service: service,
+		server:  gs,
+		conn:    conn,
+	}, nil
+}
+
+// Close closes the client and stops the server
+func (s *Server) Close() {
+	s.conn.Close()
+	s.server.Stop()
+}
@@ -0,0 +1,64 @@
+package databustest
+
+import (
+	"context"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"testing"
+)
+
+func TestNewServer(t *testing.T) {
+	s, err := NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	resp, err := s.Client.SayHello(context.TODO(), &pb.SayHelloReq{Greet: "databustest"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Response != "server response to: databustest" {
+		t.Errorf("SayHello resp: %v", resp)
+	}
+}
+
+func TestUpdateConfig(t *testing.T) {
+	s, err := NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	getResp, err := s.Client.GetConfig(context.TODO(), &pb.GetConfigReq{Name: "item_list"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if getResp.Content != "" {
+		t.Fatalf("content of a missing table: %v", getResp.Content)
+	}
+	updateReq := &pb.UpdateConfigReq{
+		Name: "item_list",
+		Head: &pb.TableHead{
+			Fields: []string{"sid", "name"},
+			Types:  []string{"int", "string"},
+			Descs:  []string{"流水ID", "名称"},
+		},
+		Content: `[{"name":"名称1","sid":1},{"name":"名称2","sid":2}]`,
+	}
+	resp, err := s.Client.UpdateConfig(context.TODO(), updateReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != 0 {
+		t.Fatalf("UpdateConfig resp: %v", resp)
+	}
+	getResp, err = s.Client.GetConfig(context.TODO(), &pb.GetConfigReq{Name: "item_list"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if getResp.Content != updateReq.Content {
+		t.Fatalf("content is diffrent, %v", getResp.Content)
+	}
+	names, _ := s.Storage.ListTables(context.TODO())
+	if len(names) != 1 || names[0] != "item_list" {
+		t.Errorf("stored tables: %v", names)
+	}
+}
@@ -3,12 +3,15 @@ module github.com/fandypeng/e2cdatabus
 go 1.14
 
 require (
+	github.com/alicebob/miniredis/v2 v2.30.0
 	github.com/go-redis/redis v6.15.9+incompatible
 	github.com/go-sql-driver/mysql v1.5.0
 	github.com/golang/protobuf v1.4.3
 	github.com/google/go-cmp v0.5.2 // indirect
 	github.com/jmoiron/sqlx v1.3.1
 	github.com/kr/text v0.2.0 // indirect
+	github.com/lib/pq v1.9.0
+	github.com/mattn/go-sqlite3 v1.14.6
 	github.com/niemeyer/pretty v0.0.0-20200227124842-a10e7caefd8e // indirect
 	github.com/onsi/ginkgo v1.10.1 // indirect
 	github.com/onsi/gomega v1.7.0 // indirect
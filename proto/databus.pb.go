@@ -29,12 +29,26 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type TableHead struct {
-	Fields               []string `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
-	Types                []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
-	Descs                []string `protobuf:"bytes,3,rep,name=descs,proto3" json:"descs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Fields []string `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	Types  []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	Descs  []string `protobuf:"bytes,3,rep,name=descs,proto3" json:"descs,omitempty"`
+	// primaryKey lists the fields of the primary key, default is the first field
+	PrimaryKey []string `protobuf:"bytes,4,rep,name=primaryKey,proto3" json:"primaryKey,omitempty"`
+	// noPrimaryKey is set for tables without a natural unique key
+	NoPrimaryKey bool          `protobuf:"varint,5,opt,name=noPrimaryKey,proto3" json:"noPrimaryKey,omitempty"`
+	Uniques      []*TableIndex `protobuf:"bytes,6,rep,name=uniques,proto3" json:"uniques,omitempty"`
+	Indexes      []*TableIndex `protobuf:"bytes,7,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	// nullable lists the fields which can be null, cells missing from a row are null in these fields
+	Nullable []string `protobuf:"bytes,8,rep,name=nullable,proto3" json:"nullable,omitempty"`
+	// defaults are the values of cells missing from a row, keyed by field, e.g. {"count": "1"}.
+	// Values of json and array fields are json text, e.g. {"items": "[1,2]"}
+	Defaults map[string]string `protobuf:"bytes,9,rep,name=defaults,proto3" json:"defaults,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// rules are the validation rules of the cells keyed by field
+	Rules                map[string]*ColumnRule `protobuf:"bytes,10,rep,name=rules,proto3" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	References           []*TableReference      `protobuf:"bytes,11,rep,name=references,proto3" json:"references,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
 func (m *TableHead) Reset()         { *m = TableHead{} }
@@ -91,28 +105,87 @@ func (m *TableHead) GetDescs() []string {
 	return nil
 }
 
-type UpdateConfigReq struct {
-	Name                 string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Head                 *TableHead `protobuf:"bytes,2,opt,name=head,proto3" json:"head,omitempty"`
-	Content              string     `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	DingtalkID           string     `protobuf:"bytes,4,opt,name=dingtalkID,proto3" json:"dingtalkID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *TableHead) GetPrimaryKey() []string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return nil
 }
 
-func (m *UpdateConfigReq) Reset()         { *m = UpdateConfigReq{} }
-func (m *UpdateConfigReq) String() string { return proto.CompactTextString(m) }
-func (*UpdateConfigReq) ProtoMessage()    {}
-func (*UpdateConfigReq) Descriptor() ([]byte, []int) {
+func (m *TableHead) GetNoPrimaryKey() bool {
+	if m != nil {
+		return m.NoPrimaryKey
+	}
+	return false
+}
+
+func (m *TableHead) GetUniques() []*TableIndex {
+	if m != nil {
+		return m.Uniques
+	}
+	return nil
+}
+
+func (m *TableHead) GetIndexes() []*TableIndex {
+	if m != nil {
+		return m.Indexes
+	}
+	return nil
+}
+
+func (m *TableHead) GetNullable() []string {
+	if m != nil {
+		return m.Nullable
+	}
+	return nil
+}
+
+func (m *TableHead) GetDefaults() map[string]string {
+	if m != nil {
+		return m.Defaults
+	}
+	return nil
+}
+
+func (m *TableHead) GetRules() map[string]*ColumnRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *TableHead) GetReferences() []*TableReference {
+	if m != nil {
+		return m.References
+	}
+	return nil
+}
+
+// tableReference declares that the values of fields must exist in another config table,
+// e.g. the itemID of drop_list references item_list. Cells of an array field are checked
+// element by element, and rows with null cells are not checked.
+type TableReference struct {
+	Fields []string `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	Table  string   `protobuf:"bytes,2,opt,name=table,proto3" json:"table,omitempty"`
+	// tableFields are the referenced fields, default is the primary key of the table
+	TableFields          []string `protobuf:"bytes,3,rep,name=tableFields,proto3" json:"tableFields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TableReference) Reset()         { *m = TableReference{} }
+func (m *TableReference) String() string { return proto.CompactTextString(m) }
+func (*TableReference) ProtoMessage()    {}
+func (*TableReference) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5732379159b89c71, []int{1}
 }
-func (m *UpdateConfigReq) XXX_Unmarshal(b []byte) error {
+func (m *TableReference) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UpdateConfigReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TableReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UpdateConfigReq.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TableReference.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -122,66 +195,70 @@ func (m *UpdateConfigReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *UpdateConfigReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateConfigReq.Merge(m, src)
+func (m *TableReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TableReference.Merge(m, src)
 }
-func (m *UpdateConfigReq) XXX_Size() int {
+func (m *TableReference) XXX_Size() int {
 	return m.Size()
 }
-func (m *UpdateConfigReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateConfigReq.DiscardUnknown(m)
+func (m *TableReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_TableReference.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateConfigReq proto.InternalMessageInfo
-
-func (m *UpdateConfigReq) GetName() string {
-	if m != nil {
-		return m.Name
-	}
-	return ""
-}
+var xxx_messageInfo_TableReference proto.InternalMessageInfo
 
-func (m *UpdateConfigReq) GetHead() *TableHead {
+func (m *TableReference) GetFields() []string {
 	if m != nil {
-		return m.Head
+		return m.Fields
 	}
 	return nil
 }
 
-func (m *UpdateConfigReq) GetContent() string {
+func (m *TableReference) GetTable() string {
 	if m != nil {
-		return m.Content
+		return m.Table
 	}
 	return ""
 }
 
-func (m *UpdateConfigReq) GetDingtalkID() string {
+func (m *TableReference) GetTableFields() []string {
 	if m != nil {
-		return m.DingtalkID
+		return m.TableFields
 	}
-	return ""
+	return nil
 }
 
-type UpdateConfigResp struct {
-	Status               int32    `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	ErrMsg               string   `protobuf:"bytes,2,opt,name=errMsg,proto3" json:"errMsg,omitempty"`
+// columnRule constrains the cells of a column, empty options are not checked.
+// Rules of array columns apply to each element, except nonEmpty and maxLength
+// which apply to the array itself.
+type ColumnRule struct {
+	// min and max are the inclusive range of numbers, e.g. "0", "99.5"
+	Min string `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max string `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
+	// pattern is a regular expression the whole text of the cell must match
+	Pattern string `protobuf:"bytes,3,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// maxLength is the max number of characters of strings, or elements of arrays
+	MaxLength int32    `protobuf:"varint,4,opt,name=maxLength,proto3" json:"maxLength,omitempty"`
+	OneOf     []string `protobuf:"bytes,5,rep,name=oneOf,proto3" json:"oneOf,omitempty"`
+	// nonEmpty rejects missing cells, empty strings and empty arrays
+	NonEmpty             bool     `protobuf:"varint,6,opt,name=nonEmpty,proto3" json:"nonEmpty,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateConfigResp) Reset()         { *m = UpdateConfigResp{} }
-func (m *UpdateConfigResp) String() string { return proto.CompactTextString(m) }
-func (*UpdateConfigResp) ProtoMessage()    {}
-func (*UpdateConfigResp) Descriptor() ([]byte, []int) {
+func (m *ColumnRule) Reset()         { *m = ColumnRule{} }
+func (m *ColumnRule) String() string { return proto.CompactTextString(m) }
+func (*ColumnRule) ProtoMessage()    {}
+func (*ColumnRule) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5732379159b89c71, []int{2}
 }
-func (m *UpdateConfigResp) XXX_Unmarshal(b []byte) error {
+func (m *ColumnRule) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UpdateConfigResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ColumnRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UpdateConfigResp.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ColumnRule.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -191,51 +268,82 @@ func (m *UpdateConfigResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *UpdateConfigResp) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateConfigResp.Merge(m, src)
+func (m *ColumnRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ColumnRule.Merge(m, src)
 }
-func (m *UpdateConfigResp) XXX_Size() int {
+func (m *ColumnRule) XXX_Size() int {
 	return m.Size()
 }
-func (m *UpdateConfigResp) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateConfigResp.DiscardUnknown(m)
+func (m *ColumnRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_ColumnRule.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateConfigResp proto.InternalMessageInfo
+var xxx_messageInfo_ColumnRule proto.InternalMessageInfo
 
-func (m *UpdateConfigResp) GetStatus() int32 {
+func (m *ColumnRule) GetMin() string {
 	if m != nil {
-		return m.Status
+		return m.Min
 	}
-	return 0
+	return ""
 }
 
-func (m *UpdateConfigResp) GetErrMsg() string {
+func (m *ColumnRule) GetMax() string {
 	if m != nil {
-		return m.ErrMsg
+		return m.Max
 	}
 	return ""
 }
 
-type GetConfigReq struct {
+func (m *ColumnRule) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *ColumnRule) GetMaxLength() int32 {
+	if m != nil {
+		return m.MaxLength
+	}
+	return 0
+}
+
+func (m *ColumnRule) GetOneOf() []string {
+	if m != nil {
+		return m.OneOf
+	}
+	return nil
+}
+
+func (m *ColumnRule) GetNonEmpty() bool {
+	if m != nil {
+		return m.NonEmpty
+	}
+	return false
+}
+
+// tableIndex is a unique constraint or a secondary index over some fields of a table,
+// the name is generated from the fields if it's empty
+type TableIndex struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Fields               []string `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetConfigReq) Reset()         { *m = GetConfigReq{} }
-func (m *GetConfigReq) String() string { return proto.CompactTextString(m) }
-func (*GetConfigReq) ProtoMessage()    {}
-func (*GetConfigReq) Descriptor() ([]byte, []int) {
+func (m *TableIndex) Reset()         { *m = TableIndex{} }
+func (m *TableIndex) String() string { return proto.CompactTextString(m) }
+func (*TableIndex) ProtoMessage()    {}
+func (*TableIndex) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5732379159b89c71, []int{3}
 }
-func (m *GetConfigReq) XXX_Unmarshal(b []byte) error {
+func (m *TableIndex) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetConfigReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TableIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetConfigReq.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TableIndex.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -245,44 +353,63 @@ func (m *GetConfigReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *GetConfigReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetConfigReq.Merge(m, src)
+func (m *TableIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TableIndex.Merge(m, src)
 }
-func (m *GetConfigReq) XXX_Size() int {
+func (m *TableIndex) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetConfigReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetConfigReq.DiscardUnknown(m)
+func (m *TableIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_TableIndex.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetConfigReq proto.InternalMessageInfo
+var xxx_messageInfo_TableIndex proto.InternalMessageInfo
 
-func (m *GetConfigReq) GetName() string {
+func (m *TableIndex) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-type GetConfigResp struct {
-	Content              string   `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+func (m *TableIndex) GetFields() []string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+type UpdateConfigReq struct {
+	Name       string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Head       *TableHead `protobuf:"bytes,2,opt,name=head,proto3" json:"head,omitempty"`
+	Content    string     `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	DingtalkID string     `protobuf:"bytes,4,opt,name=dingtalkID,proto3" json:"dingtalkID,omitempty"`
+	// allowBreaking applies breaking schema changes when the server rejects them
+	AllowBreaking bool `protobuf:"varint,5,opt,name=allowBreaking,proto3" json:"allowBreaking,omitempty"`
+	// dryRun validates and diffs the upload, and checks the writes of the sql storages,
+	// postgres and sqlite in a transaction which is rolled back, and mysql in a scratch
+	// table which is dropped. Nothing is published or notified.
+	DryRun bool `protobuf:"varint,6,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	// stage writes the upload into the staging slot of the table instead of publishing it,
+	// see PublishConfig and DiscardStaged
+	Stage                bool     `protobuf:"varint,7,opt,name=stage,proto3" json:"stage,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetConfigResp) Reset()         { *m = GetConfigResp{} }
-func (m *GetConfigResp) String() string { return proto.CompactTextString(m) }
-func (*GetConfigResp) ProtoMessage()    {}
-func (*GetConfigResp) Descriptor() ([]byte, []int) {
+func (m *UpdateConfigReq) Reset()         { *m = UpdateConfigReq{} }
+func (m *UpdateConfigReq) String() string { return proto.CompactTextString(m) }
+func (*UpdateConfigReq) ProtoMessage()    {}
+func (*UpdateConfigReq) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5732379159b89c71, []int{4}
 }
-func (m *GetConfigResp) XXX_Unmarshal(b []byte) error {
+func (m *UpdateConfigReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetConfigResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpdateConfigReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetConfigResp.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpdateConfigReq.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -292,44 +419,97 @@ func (m *GetConfigResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *GetConfigResp) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetConfigResp.Merge(m, src)
+func (m *UpdateConfigReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateConfigReq.Merge(m, src)
 }
-func (m *GetConfigResp) XXX_Size() int {
+func (m *UpdateConfigReq) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetConfigResp) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetConfigResp.DiscardUnknown(m)
+func (m *UpdateConfigReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateConfigReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetConfigResp proto.InternalMessageInfo
+var xxx_messageInfo_UpdateConfigReq proto.InternalMessageInfo
 
-func (m *GetConfigResp) GetContent() string {
+func (m *UpdateConfigReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateConfigReq) GetHead() *TableHead {
+	if m != nil {
+		return m.Head
+	}
+	return nil
+}
+
+func (m *UpdateConfigReq) GetContent() string {
 	if m != nil {
 		return m.Content
 	}
 	return ""
 }
 
-type SayHelloReq struct {
-	Greet                string   `protobuf:"bytes,1,opt,name=greet,proto3" json:"greet,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *UpdateConfigReq) GetDingtalkID() string {
+	if m != nil {
+		return m.DingtalkID
+	}
+	return ""
 }
 
-func (m *SayHelloReq) Reset()         { *m = SayHelloReq{} }
-func (m *SayHelloReq) String() string { return proto.CompactTextString(m) }
-func (*SayHelloReq) ProtoMessage()    {}
-func (*SayHelloReq) Descriptor() ([]byte, []int) {
+func (m *UpdateConfigReq) GetAllowBreaking() bool {
+	if m != nil {
+		return m.AllowBreaking
+	}
+	return false
+}
+
+func (m *UpdateConfigReq) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *UpdateConfigReq) GetStage() bool {
+	if m != nil {
+		return m.Stage
+	}
+	return false
+}
+
+type UpdateConfigResp struct {
+	Status int32         `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	ErrMsg string        `protobuf:"bytes,2,opt,name=errMsg,proto3" json:"errMsg,omitempty"`
+	Sinks  []*SinkStatus `protobuf:"bytes,3,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	// violations are the problems of the uploaded table, nothing is written if there is any
+	Violations []*Violation `protobuf:"bytes,4,rep,name=violations,proto3" json:"violations,omitempty"`
+	// schemaChanges are the changes of the head against the stored schema
+	SchemaChanges []*SchemaChange `protobuf:"bytes,5,rep,name=schemaChanges,proto3" json:"schemaChanges,omitempty"`
+	// version is the number of the uploaded version of the table,
+	// it's 0 if no history store is set or the version is not recorded, see StatusVersionFailed
+	Version int64 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	// rows are the row changes against the stored table, they're only set for dry runs
+	Rows                 []*RowChange `protobuf:"bytes,7,rep,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *UpdateConfigResp) Reset()         { *m = UpdateConfigResp{} }
+func (m *UpdateConfigResp) String() string { return proto.CompactTextString(m) }
+func (*UpdateConfigResp) ProtoMessage()    {}
+func (*UpdateConfigResp) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5732379159b89c71, []int{5}
 }
-func (m *SayHelloReq) XXX_Unmarshal(b []byte) error {
+func (m *UpdateConfigResp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SayHelloReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpdateConfigResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SayHelloReq.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpdateConfigResp.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -339,44 +519,95 @@ func (m *SayHelloReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *SayHelloReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SayHelloReq.Merge(m, src)
+func (m *UpdateConfigResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateConfigResp.Merge(m, src)
 }
-func (m *SayHelloReq) XXX_Size() int {
+func (m *UpdateConfigResp) XXX_Size() int {
 	return m.Size()
 }
-func (m *SayHelloReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_SayHelloReq.DiscardUnknown(m)
+func (m *UpdateConfigResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateConfigResp.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SayHelloReq proto.InternalMessageInfo
+var xxx_messageInfo_UpdateConfigResp proto.InternalMessageInfo
 
-func (m *SayHelloReq) GetGreet() string {
+func (m *UpdateConfigResp) GetStatus() int32 {
 	if m != nil {
-		return m.Greet
+		return m.Status
+	}
+	return 0
+}
+
+func (m *UpdateConfigResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
 	}
 	return ""
 }
 
-type SayHelloResp struct {
-	Response             string   `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *UpdateConfigResp) GetSinks() []*SinkStatus {
+	if m != nil {
+		return m.Sinks
+	}
+	return nil
 }
 
-func (m *SayHelloResp) Reset()         { *m = SayHelloResp{} }
-func (m *SayHelloResp) String() string { return proto.CompactTextString(m) }
-func (*SayHelloResp) ProtoMessage()    {}
-func (*SayHelloResp) Descriptor() ([]byte, []int) {
+func (m *UpdateConfigResp) GetViolations() []*Violation {
+	if m != nil {
+		return m.Violations
+	}
+	return nil
+}
+
+func (m *UpdateConfigResp) GetSchemaChanges() []*SchemaChange {
+	if m != nil {
+		return m.SchemaChanges
+	}
+	return nil
+}
+
+func (m *UpdateConfigResp) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *UpdateConfigResp) GetRows() []*RowChange {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+// SchemaChange is a column change of an uploaded head against the stored one
+type SchemaChange struct {
+	// kind is one of "added", "removed", "retyped" and "renamed"
+	Kind  string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	// oldField is the field before it's renamed
+	OldField string `protobuf:"bytes,3,opt,name=oldField,proto3" json:"oldField,omitempty"`
+	OldType  string `protobuf:"bytes,4,opt,name=oldType,proto3" json:"oldType,omitempty"`
+	NewType  string `protobuf:"bytes,5,opt,name=newType,proto3" json:"newType,omitempty"`
+	// breaking is true for changes which may break the readers of the table
+	Breaking             bool     `protobuf:"varint,6,opt,name=breaking,proto3" json:"breaking,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SchemaChange) Reset()         { *m = SchemaChange{} }
+func (m *SchemaChange) String() string { return proto.CompactTextString(m) }
+func (*SchemaChange) ProtoMessage()    {}
+func (*SchemaChange) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5732379159b89c71, []int{6}
 }
-func (m *SayHelloResp) XXX_Unmarshal(b []byte) error {
+func (m *SchemaChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SayHelloResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SchemaChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SayHelloResp.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SchemaChange.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -386,666 +617,6533 @@ func (m *SayHelloResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *SayHelloResp) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SayHelloResp.Merge(m, src)
+func (m *SchemaChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaChange.Merge(m, src)
 }
-func (m *SayHelloResp) XXX_Size() int {
+func (m *SchemaChange) XXX_Size() int {
 	return m.Size()
 }
-func (m *SayHelloResp) XXX_DiscardUnknown() {
-	xxx_messageInfo_SayHelloResp.DiscardUnknown(m)
+func (m *SchemaChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaChange.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SayHelloResp proto.InternalMessageInfo
+var xxx_messageInfo_SchemaChange proto.InternalMessageInfo
 
-func (m *SayHelloResp) GetResponse() string {
+func (m *SchemaChange) GetKind() string {
 	if m != nil {
-		return m.Response
+		return m.Kind
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*TableHead)(nil), "service.v1.tableHead")
-	proto.RegisterType((*UpdateConfigReq)(nil), "service.v1.UpdateConfigReq")
-	proto.RegisterType((*UpdateConfigResp)(nil), "service.v1.UpdateConfigResp")
-	proto.RegisterType((*GetConfigReq)(nil), "service.v1.GetConfigReq")
-	proto.RegisterType((*GetConfigResp)(nil), "service.v1.GetConfigResp")
-	proto.RegisterType((*SayHelloReq)(nil), "service.v1.SayHelloReq")
-	proto.RegisterType((*SayHelloResp)(nil), "service.v1.SayHelloResp")
+func (m *SchemaChange) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
 }
 
-func init() { proto.RegisterFile("databus.proto", fileDescriptor_5732379159b89c71) }
-
-var fileDescriptor_5732379159b89c71 = []byte{
-	// 393 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0xcd, 0xce, 0xd2, 0x40,
-	0x14, 0x65, 0xf8, 0xef, 0x05, 0xa2, 0x99, 0xa0, 0x0e, 0xd5, 0x34, 0x64, 0xdc, 0x80, 0x8b, 0x26,
-	0xe2, 0xd2, 0x85, 0x09, 0x92, 0x88, 0x31, 0xc6, 0xa4, 0xc6, 0x8d, 0xbb, 0x81, 0x5e, 0x6a, 0x63,
-	0x6d, 0xc7, 0xce, 0x40, 0xc2, 0x2b, 0xf8, 0x04, 0x3e, 0x92, 0x4b, 0xf7, 0x6e, 0x0c, 0xbe, 0x88,
-	0xe9, 0x94, 0xc2, 0xf0, 0xe5, 0xe3, 0x5b, 0xb5, 0xe7, 0xdc, 0x93, 0xd3, 0x73, 0xee, 0x2d, 0x0c,
-	0x42, 0xa1, 0xc5, 0x6a, 0xab, 0x7c, 0x99, 0x67, 0x3a, 0xa3, 0xa0, 0x30, 0xdf, 0xc5, 0x6b, 0xf4,
-	0x77, 0xcf, 0xf9, 0x07, 0x70, 0xb4, 0x58, 0x25, 0xb8, 0x44, 0x11, 0xd2, 0x87, 0xd0, 0xde, 0xc4,
-	0x98, 0x84, 0x8a, 0x91, 0x71, 0x63, 0xe2, 0x04, 0x47, 0x44, 0x87, 0xd0, 0xd2, 0x7b, 0x89, 0x8a,
-	0xd5, 0x0d, 0x5d, 0x82, 0x82, 0x0d, 0x51, 0xad, 0x15, 0x6b, 0x94, 0xac, 0x01, 0xfc, 0x07, 0x81,
-	0x7b, 0x9f, 0x64, 0x28, 0x34, 0xbe, 0xce, 0xd2, 0x4d, 0x1c, 0x05, 0xf8, 0x9d, 0x52, 0x68, 0xa6,
-	0xe2, 0x1b, 0x32, 0x32, 0x26, 0x13, 0x27, 0x30, 0xef, 0x74, 0x0a, 0xcd, 0x2f, 0x28, 0x42, 0x56,
-	0x1f, 0x93, 0x49, 0x6f, 0xf6, 0xc0, 0x3f, 0x67, 0xf2, 0x4f, 0x81, 0x02, 0x23, 0xa1, 0x0c, 0x3a,
-	0xeb, 0x2c, 0xd5, 0x98, 0x6a, 0xd6, 0x30, 0x0e, 0x15, 0xa4, 0x1e, 0x40, 0x18, 0xa7, 0x91, 0x16,
-	0xc9, 0xd7, 0xb7, 0x0b, 0xd6, 0x34, 0x43, 0x8b, 0xe1, 0x73, 0xb8, 0x7f, 0x99, 0x45, 0xc9, 0xa2,
-	0xa4, 0xd2, 0x42, 0x6f, 0x95, 0x89, 0xd3, 0x0a, 0x8e, 0xa8, 0xe0, 0x31, 0xcf, 0xdf, 0xab, 0xc8,
-	0x44, 0x72, 0x82, 0x23, 0xe2, 0x1c, 0xfa, 0x6f, 0x50, 0xdf, 0x59, 0x86, 0x4f, 0x61, 0x60, 0x69,
-	0x94, 0xb4, 0x23, 0x93, 0x8b, 0xc8, 0xfc, 0x29, 0xf4, 0x3e, 0x8a, 0xfd, 0x12, 0x93, 0x24, 0x2b,
-	0xdc, 0x86, 0xd0, 0x8a, 0x72, 0xc4, 0x4a, 0x56, 0x02, 0xfe, 0x0c, 0xfa, 0x67, 0x91, 0x92, 0xd4,
-	0x85, 0x6e, 0x8e, 0x4a, 0x66, 0xa9, 0xaa, 0xbe, 0x7b, 0xc2, 0xb3, 0x3f, 0x04, 0x3a, 0x8b, 0xf2,
-	0xbe, 0xf4, 0x1d, 0xf4, 0xed, 0xbe, 0xf4, 0xb1, 0xbd, 0xd6, 0x1b, 0x57, 0x71, 0x9f, 0x5c, 0x1f,
-	0x2a, 0xc9, 0x6b, 0x74, 0x0e, 0xce, 0xa9, 0x14, 0x65, 0xb6, 0xd8, 0xde, 0x87, 0x3b, 0xba, 0x32,
-	0x31, 0x1e, 0xaf, 0xa0, 0x5b, 0x15, 0xa1, 0x8f, 0x6c, 0xa1, 0xb5, 0x03, 0x97, 0xdd, 0x3e, 0x28,
-	0x0c, 0xe6, 0xa3, 0x5f, 0x07, 0x8f, 0xfc, 0x3e, 0x78, 0xe4, 0xef, 0xc1, 0x23, 0x3f, 0xff, 0x79,
-	0xb5, 0xcf, 0x1d, 0xff, 0xa5, 0xf9, 0x8d, 0x57, 0x6d, 0xf3, 0x78, 0xf1, 0x3f, 0x00, 0x00, 0xff,
-	0xff, 0xb7, 0x70, 0xb5, 0xbd, 0xde, 0x02, 0x00, 0x00,
+func (m *SchemaChange) GetOldField() string {
+	if m != nil {
+		return m.OldField
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *SchemaChange) GetOldType() string {
+	if m != nil {
+		return m.OldType
+	}
+	return ""
+}
 
-// DatabusClient is the client API for Databus service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DatabusClient interface {
-	UpdateConfig(ctx context.Context, in *UpdateConfigReq, opts ...grpc.CallOption) (*UpdateConfigResp, error)
-	GetConfig(ctx context.Context, in *GetConfigReq, opts ...grpc.CallOption) (*GetConfigResp, error)
-	SayHello(ctx context.Context, in *SayHelloReq, opts ...grpc.CallOption) (*SayHelloResp, error)
+func (m *SchemaChange) GetNewType() string {
+	if m != nil {
+		return m.NewType
+	}
+	return ""
 }
 
-type databusClient struct {
-	cc *grpc.ClientConn
+func (m *SchemaChange) GetBreaking() bool {
+	if m != nil {
+		return m.Breaking
+	}
+	return false
 }
 
-func NewDatabusClient(cc *grpc.ClientConn) DatabusClient {
-	return &databusClient{cc}
+// Violation is a problem found by validating the uploaded table against its head
+type Violation struct {
+	// row is the 1-based row of content, 0 for problems of the head or the whole content
+	Row                  int32    `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Column               string   `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *databusClient) UpdateConfig(ctx context.Context, in *UpdateConfigReq, opts ...grpc.CallOption) (*UpdateConfigResp, error) {
-	out := new(UpdateConfigResp)
-	err := c.cc.Invoke(ctx, "/service.v1.Databus/UpdateConfig", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Violation) Reset()         { *m = Violation{} }
+func (m *Violation) String() string { return proto.CompactTextString(m) }
+func (*Violation) ProtoMessage()    {}
+func (*Violation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{7}
+}
+func (m *Violation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Violation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Violation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *Violation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Violation.Merge(m, src)
+}
+func (m *Violation) XXX_Size() int {
+	return m.Size()
+}
+func (m *Violation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Violation.DiscardUnknown(m)
 }
 
-func (c *databusClient) GetConfig(ctx context.Context, in *GetConfigReq, opts ...grpc.CallOption) (*GetConfigResp, error) {
-	out := new(GetConfigResp)
-	err := c.cc.Invoke(ctx, "/service.v1.Databus/GetConfig", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Violation proto.InternalMessageInfo
+
+func (m *Violation) GetRow() int32 {
+	if m != nil {
+		return m.Row
 	}
-	return out, nil
+	return 0
 }
 
-func (c *databusClient) SayHello(ctx context.Context, in *SayHelloReq, opts ...grpc.CallOption) (*SayHelloResp, error) {
-	out := new(SayHelloResp)
-	err := c.cc.Invoke(ctx, "/service.v1.Databus/SayHello", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Violation) GetColumn() string {
+	if m != nil {
+		return m.Column
 	}
-	return out, nil
+	return ""
 }
 
-// DatabusServer is the server API for Databus service.
-type DatabusServer interface {
-	UpdateConfig(context.Context, *UpdateConfigReq) (*UpdateConfigResp, error)
-	GetConfig(context.Context, *GetConfigReq) (*GetConfigResp, error)
-	SayHello(context.Context, *SayHelloReq) (*SayHelloResp, error)
+func (m *Violation) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
 }
 
-// UnimplementedDatabusServer can be embedded to have forward compatible implementations.
-type UnimplementedDatabusServer struct {
+// SinkStatus is the write result of one storage backend
+type SinkStatus struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status               int32    `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	ErrMsg               string   `protobuf:"bytes,3,opt,name=errMsg,proto3" json:"errMsg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (*UnimplementedDatabusServer) UpdateConfig(ctx context.Context, req *UpdateConfigReq) (*UpdateConfigResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfig not implemented")
+func (m *SinkStatus) Reset()         { *m = SinkStatus{} }
+func (m *SinkStatus) String() string { return proto.CompactTextString(m) }
+func (*SinkStatus) ProtoMessage()    {}
+func (*SinkStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{8}
 }
-func (*UnimplementedDatabusServer) GetConfig(ctx context.Context, req *GetConfigReq) (*GetConfigResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+func (m *SinkStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedDatabusServer) SayHello(ctx context.Context, req *SayHelloReq) (*SayHelloResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SayHello not implemented")
+func (m *SinkStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SinkStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-
-func RegisterDatabusServer(s *grpc.Server, srv DatabusServer) {
-	s.RegisterService(&_Databus_serviceDesc, srv)
+func (m *SinkStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SinkStatus.Merge(m, src)
 }
-
-func _Databus_UpdateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateConfigReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DatabusServer).UpdateConfig(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/service.v1.Databus/UpdateConfig",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DatabusServer).UpdateConfig(ctx, req.(*UpdateConfigReq))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *SinkStatus) XXX_Size() int {
+	return m.Size()
 }
-
-func _Databus_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetConfigReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DatabusServer).GetConfig(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/service.v1.Databus/GetConfig",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DatabusServer).GetConfig(ctx, req.(*GetConfigReq))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *SinkStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SinkStatus.DiscardUnknown(m)
 }
 
-func _Databus_SayHello_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SayHelloReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DatabusServer).SayHello(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/service.v1.Databus/SayHello",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DatabusServer).SayHello(ctx, req.(*SayHelloReq))
+var xxx_messageInfo_SinkStatus proto.InternalMessageInfo
+
+func (m *SinkStatus) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-var _Databus_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "service.v1.Databus",
-	HandlerType: (*DatabusServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "UpdateConfig",
-			Handler:    _Databus_UpdateConfig_Handler,
-		},
-		{
-			MethodName: "GetConfig",
-			Handler:    _Databus_GetConfig_Handler,
-		},
-		{
-			MethodName: "SayHello",
-			Handler:    _Databus_SayHello_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "databus.proto",
+func (m *SinkStatus) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
 }
 
-func (m *TableHead) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SinkStatus) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *TableHead) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type GetConfigReq struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TableHead) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Descs) > 0 {
-		for iNdEx := len(m.Descs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Descs[iNdEx])
-			copy(dAtA[i:], m.Descs[iNdEx])
-			i = encodeVarintDatabus(dAtA, i, uint64(len(m.Descs[iNdEx])))
-			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if len(m.Types) > 0 {
-		for iNdEx := len(m.Types) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Types[iNdEx])
-			copy(dAtA[i:], m.Types[iNdEx])
-			i = encodeVarintDatabus(dAtA, i, uint64(len(m.Types[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if len(m.Fields) > 0 {
-		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Fields[iNdEx])
-			copy(dAtA[i:], m.Fields[iNdEx])
-			i = encodeVarintDatabus(dAtA, i, uint64(len(m.Fields[iNdEx])))
-			i--
-			dAtA[i] = 0xa
+func (m *GetConfigReq) Reset()         { *m = GetConfigReq{} }
+func (m *GetConfigReq) String() string { return proto.CompactTextString(m) }
+func (*GetConfigReq) ProtoMessage()    {}
+func (*GetConfigReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{9}
+}
+func (m *GetConfigReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetConfigReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetConfigReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *GetConfigReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigReq.Merge(m, src)
+}
+func (m *GetConfigReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetConfigReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigReq.DiscardUnknown(m)
 }
 
-func (m *UpdateConfigReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetConfigReq proto.InternalMessageInfo
+
+func (m *GetConfigReq) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *UpdateConfigReq) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type GetConfigResp struct {
+	Content              string   `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateConfigReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.DingtalkID) > 0 {
-		i -= len(m.DingtalkID)
-		copy(dAtA[i:], m.DingtalkID)
-		i = encodeVarintDatabus(dAtA, i, uint64(len(m.DingtalkID)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.Content) > 0 {
-		i -= len(m.Content)
-		copy(dAtA[i:], m.Content)
-		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Content)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Head != nil {
-		{
-			size, err := m.Head.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintDatabus(dAtA, i, uint64(size))
+func (m *GetConfigResp) Reset()         { *m = GetConfigResp{} }
+func (m *GetConfigResp) String() string { return proto.CompactTextString(m) }
+func (*GetConfigResp) ProtoMessage()    {}
+func (*GetConfigResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{10}
+}
+func (m *GetConfigResp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetConfigResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetConfigResp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *GetConfigResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigResp.Merge(m, src)
+}
+func (m *GetConfigResp) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetConfigResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigResp.DiscardUnknown(m)
 }
 
-func (m *UpdateConfigResp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetConfigResp proto.InternalMessageInfo
+
+func (m *GetConfigResp) GetContent() string {
+	if m != nil {
+		return m.Content
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *UpdateConfigResp) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type GetSchemaReq struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateConfigResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ErrMsg) > 0 {
-		i -= len(m.ErrMsg)
-		copy(dAtA[i:], m.ErrMsg)
-		i = encodeVarintDatabus(dAtA, i, uint64(len(m.ErrMsg)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Status != 0 {
-		i = encodeVarintDatabus(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
+func (m *GetSchemaReq) Reset()         { *m = GetSchemaReq{} }
+func (m *GetSchemaReq) String() string { return proto.CompactTextString(m) }
+func (*GetSchemaReq) ProtoMessage()    {}
+func (*GetSchemaReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{11}
+}
+func (m *GetSchemaReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetSchemaReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetSchemaReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *GetSchemaReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSchemaReq.Merge(m, src)
+}
+func (m *GetSchemaReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetSchemaReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSchemaReq.DiscardUnknown(m)
 }
 
-func (m *GetConfigReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetSchemaReq proto.InternalMessageInfo
+
+func (m *GetSchemaReq) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *GetConfigReq) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// GetSchemaResp has no head if the table has never been uploaded
+type GetSchemaResp struct {
+	Head                 *TableHead `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
-func (m *GetConfigReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *GetSchemaResp) Reset()         { *m = GetSchemaResp{} }
+func (m *GetSchemaResp) String() string { return proto.CompactTextString(m) }
+func (*GetSchemaResp) ProtoMessage()    {}
+func (*GetSchemaResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{12}
+}
+func (m *GetSchemaResp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetSchemaResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetSchemaResp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *GetSchemaResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSchemaResp.Merge(m, src)
+}
+func (m *GetSchemaResp) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetSchemaResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSchemaResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSchemaResp proto.InternalMessageInfo
+
+func (m *GetSchemaResp) GetHead() *TableHead {
+	if m != nil {
+		return m.Head
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *GetConfigResp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type RollbackConfigReq struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// version is the number of the version to restore
+	Version              int64    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	DingtalkID           string   `protobuf:"bytes,3,opt,name=dingtalkID,proto3" json:"dingtalkID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetConfigResp) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *RollbackConfigReq) Reset()         { *m = RollbackConfigReq{} }
+func (m *RollbackConfigReq) String() string { return proto.CompactTextString(m) }
+func (*RollbackConfigReq) ProtoMessage()    {}
+func (*RollbackConfigReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{13}
+}
+func (m *RollbackConfigReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RollbackConfigReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RollbackConfigReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RollbackConfigReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackConfigReq.Merge(m, src)
+}
+func (m *RollbackConfigReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *RollbackConfigReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackConfigReq.DiscardUnknown(m)
 }
 
-func (m *GetConfigResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_RollbackConfigReq proto.InternalMessageInfo
+
+func (m *RollbackConfigReq) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	if len(m.Content) > 0 {
-		i -= len(m.Content)
-		copy(dAtA[i:], m.Content)
-		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Content)))
-		i--
-		dAtA[i] = 0xa
+	return ""
+}
+
+func (m *RollbackConfigReq) GetVersion() int64 {
+	if m != nil {
+		return m.Version
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *SayHelloReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *RollbackConfigReq) GetDingtalkID() string {
+	if m != nil {
+		return m.DingtalkID
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *SayHelloReq) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// RollbackConfigResp is like UpdateConfigResp, the restored content is recorded as a new version
+type RollbackConfigResp struct {
+	Status               int32         `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	ErrMsg               string        `protobuf:"bytes,2,opt,name=errMsg,proto3" json:"errMsg,omitempty"`
+	Sinks                []*SinkStatus `protobuf:"bytes,3,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	Version              int64         `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *SayHelloReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Greet) > 0 {
-		i -= len(m.Greet)
-		copy(dAtA[i:], m.Greet)
-		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Greet)))
-		i--
-		dAtA[i] = 0xa
+func (m *RollbackConfigResp) Reset()         { *m = RollbackConfigResp{} }
+func (m *RollbackConfigResp) String() string { return proto.CompactTextString(m) }
+func (*RollbackConfigResp) ProtoMessage()    {}
+func (*RollbackConfigResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{14}
+}
+func (m *RollbackConfigResp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RollbackConfigResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RollbackConfigResp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *RollbackConfigResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackConfigResp.Merge(m, src)
+}
+func (m *RollbackConfigResp) XXX_Size() int {
+	return m.Size()
+}
+func (m *RollbackConfigResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackConfigResp.DiscardUnknown(m)
 }
 
-func (m *SayHelloResp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RollbackConfigResp proto.InternalMessageInfo
+
+func (m *RollbackConfigResp) GetStatus() int32 {
+	if m != nil {
+		return m.Status
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *SayHelloResp) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *RollbackConfigResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
 }
 
-func (m *SayHelloResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Response) > 0 {
-		i -= len(m.Response)
-		copy(dAtA[i:], m.Response)
-		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Response)))
-		i--
-		dAtA[i] = 0xa
+func (m *RollbackConfigResp) GetSinks() []*SinkStatus {
+	if m != nil {
+		return m.Sinks
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func encodeVarintDatabus(dAtA []byte, offset int, v uint64) int {
-	offset -= sovDatabus(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *RollbackConfigResp) GetVersion() int64 {
+	if m != nil {
+		return m.Version
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return 0
 }
-func (m *TableHead) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Fields) > 0 {
-		for _, s := range m.Fields {
-			l = len(s)
-			n += 1 + l + sovDatabus(uint64(l))
-		}
-	}
-	if len(m.Types) > 0 {
-		for _, s := range m.Types {
-			l = len(s)
-			n += 1 + l + sovDatabus(uint64(l))
-		}
-	}
-	if len(m.Descs) > 0 {
-		for _, s := range m.Descs {
-			l = len(s)
-			n += 1 + l + sovDatabus(uint64(l))
+
+// DiffConfigReq compares a stored version of a table to another version or to a candidate upload
+type DiffConfigReq struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// fromVersion is the version to compare from, 0 means the table currently stored
+	FromVersion int64 `protobuf:"varint,2,opt,name=fromVersion,proto3" json:"fromVersion,omitempty"`
+	// toVersion is the version to compare to, it's ignored if candidate is set
+	ToVersion            int64            `protobuf:"varint,3,opt,name=toVersion,proto3" json:"toVersion,omitempty"`
+	Candidate            *UpdateConfigReq `protobuf:"bytes,4,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *DiffConfigReq) Reset()         { *m = DiffConfigReq{} }
+func (m *DiffConfigReq) String() string { return proto.CompactTextString(m) }
+func (*DiffConfigReq) ProtoMessage()    {}
+func (*DiffConfigReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{15}
+}
+func (m *DiffConfigReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiffConfigReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiffConfigReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+}
+func (m *DiffConfigReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffConfigReq.Merge(m, src)
+}
+func (m *DiffConfigReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiffConfigReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffConfigReq.DiscardUnknown(m)
 }
 
-func (m *UpdateConfigReq) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovDatabus(uint64(l))
-	}
-	if m.Head != nil {
-		l = m.Head.Size()
-		n += 1 + l + sovDatabus(uint64(l))
-	}
-	l = len(m.Content)
-	if l > 0 {
-		n += 1 + l + sovDatabus(uint64(l))
-	}
-	l = len(m.DingtalkID)
-	if l > 0 {
-		n += 1 + l + sovDatabus(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+var xxx_messageInfo_DiffConfigReq proto.InternalMessageInfo
+
+func (m *DiffConfigReq) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return n
+	return ""
 }
 
-func (m *UpdateConfigResp) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Status != 0 {
-		n += 1 + sovDatabus(uint64(m.Status))
-	}
-	l = len(m.ErrMsg)
-	if l > 0 {
-		n += 1 + l + sovDatabus(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *DiffConfigReq) GetFromVersion() int64 {
+	if m != nil {
+		return m.FromVersion
 	}
-	return n
+	return 0
 }
 
-func (m *GetConfigReq) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovDatabus(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *DiffConfigReq) GetToVersion() int64 {
+	if m != nil {
+		return m.ToVersion
 	}
-	return n
+	return 0
 }
 
-func (m *GetConfigResp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DiffConfigReq) GetCandidate() *UpdateConfigReq {
+	if m != nil {
+		return m.Candidate
 	}
-	var l int
-	_ = l
-	l = len(m.Content)
-	if l > 0 {
-		n += 1 + l + sovDatabus(uint64(l))
+	return nil
+}
+
+type DiffConfigResp struct {
+	Rows                 []*RowChange    `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	SchemaChanges        []*SchemaChange `protobuf:"bytes,2,rep,name=schemaChanges,proto3" json:"schemaChanges,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *DiffConfigResp) Reset()         { *m = DiffConfigResp{} }
+func (m *DiffConfigResp) String() string { return proto.CompactTextString(m) }
+func (*DiffConfigResp) ProtoMessage()    {}
+func (*DiffConfigResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{16}
+}
+func (m *DiffConfigResp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiffConfigResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiffConfigResp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+}
+func (m *DiffConfigResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffConfigResp.Merge(m, src)
+}
+func (m *DiffConfigResp) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiffConfigResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffConfigResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiffConfigResp proto.InternalMessageInfo
+
+func (m *DiffConfigResp) GetRows() []*RowChange {
+	if m != nil {
+		return m.Rows
 	}
-	return n
+	return nil
 }
 
-func (m *SayHelloReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DiffConfigResp) GetSchemaChanges() []*SchemaChange {
+	if m != nil {
+		return m.SchemaChanges
 	}
-	var l int
-	_ = l
-	l = len(m.Greet)
-	if l > 0 {
-		n += 1 + l + sovDatabus(uint64(l))
+	return nil
+}
+
+// RowChange is a row added, removed or modified, rows are matched by primary key,
+// or by their index for tables without a primary key
+type RowChange struct {
+	// kind is one of "added", "removed" and "modified"
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// key is the value of the primary key, values of a composite key are joined by ":"
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// oldRow and newRow are the rows in json, oldRow is empty for added rows
+	// and newRow is empty for removed rows
+	OldRow string `protobuf:"bytes,3,opt,name=oldRow,proto3" json:"oldRow,omitempty"`
+	NewRow string `protobuf:"bytes,4,opt,name=newRow,proto3" json:"newRow,omitempty"`
+	// fields are the modified fields
+	Fields               []string `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RowChange) Reset()         { *m = RowChange{} }
+func (m *RowChange) String() string { return proto.CompactTextString(m) }
+func (*RowChange) ProtoMessage()    {}
+func (*RowChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{17}
+}
+func (m *RowChange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RowChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RowChange.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+}
+func (m *RowChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowChange.Merge(m, src)
+}
+func (m *RowChange) XXX_Size() int {
+	return m.Size()
+}
+func (m *RowChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowChange proto.InternalMessageInfo
+
+func (m *RowChange) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *RowChange) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *RowChange) GetOldRow() string {
+	if m != nil {
+		return m.OldRow
+	}
+	return ""
+}
+
+func (m *RowChange) GetNewRow() string {
+	if m != nil {
+		return m.NewRow
+	}
+	return ""
+}
+
+func (m *RowChange) GetFields() []string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+type PublishConfigReq struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// dingtalkID is who approves the staged upload
+	DingtalkID           string   `protobuf:"bytes,2,opt,name=dingtalkID,proto3" json:"dingtalkID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishConfigReq) Reset()         { *m = PublishConfigReq{} }
+func (m *PublishConfigReq) String() string { return proto.CompactTextString(m) }
+func (*PublishConfigReq) ProtoMessage()    {}
+func (*PublishConfigReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{18}
+}
+func (m *PublishConfigReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PublishConfigReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PublishConfigReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PublishConfigReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishConfigReq.Merge(m, src)
+}
+func (m *PublishConfigReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *PublishConfigReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishConfigReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishConfigReq proto.InternalMessageInfo
+
+func (m *PublishConfigReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PublishConfigReq) GetDingtalkID() string {
+	if m != nil {
+		return m.DingtalkID
+	}
+	return ""
+}
+
+type PublishConfigResp struct {
+	Status               int32         `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	ErrMsg               string        `protobuf:"bytes,2,opt,name=errMsg,proto3" json:"errMsg,omitempty"`
+	Sinks                []*SinkStatus `protobuf:"bytes,3,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	Version              int64         `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PublishConfigResp) Reset()         { *m = PublishConfigResp{} }
+func (m *PublishConfigResp) String() string { return proto.CompactTextString(m) }
+func (*PublishConfigResp) ProtoMessage()    {}
+func (*PublishConfigResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{19}
+}
+func (m *PublishConfigResp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PublishConfigResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PublishConfigResp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PublishConfigResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishConfigResp.Merge(m, src)
+}
+func (m *PublishConfigResp) XXX_Size() int {
+	return m.Size()
+}
+func (m *PublishConfigResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishConfigResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishConfigResp proto.InternalMessageInfo
+
+func (m *PublishConfigResp) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *PublishConfigResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+func (m *PublishConfigResp) GetSinks() []*SinkStatus {
+	if m != nil {
+		return m.Sinks
+	}
+	return nil
+}
+
+func (m *PublishConfigResp) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type DiscardStagedReq struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiscardStagedReq) Reset()         { *m = DiscardStagedReq{} }
+func (m *DiscardStagedReq) String() string { return proto.CompactTextString(m) }
+func (*DiscardStagedReq) ProtoMessage()    {}
+func (*DiscardStagedReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{20}
+}
+func (m *DiscardStagedReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiscardStagedReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiscardStagedReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DiscardStagedReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiscardStagedReq.Merge(m, src)
+}
+func (m *DiscardStagedReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiscardStagedReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiscardStagedReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiscardStagedReq proto.InternalMessageInfo
+
+func (m *DiscardStagedReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DiscardStagedResp struct {
+	Status               int32    `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	ErrMsg               string   `protobuf:"bytes,2,opt,name=errMsg,proto3" json:"errMsg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiscardStagedResp) Reset()         { *m = DiscardStagedResp{} }
+func (m *DiscardStagedResp) String() string { return proto.CompactTextString(m) }
+func (*DiscardStagedResp) ProtoMessage()    {}
+func (*DiscardStagedResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{21}
+}
+func (m *DiscardStagedResp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiscardStagedResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiscardStagedResp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DiscardStagedResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiscardStagedResp.Merge(m, src)
+}
+func (m *DiscardStagedResp) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiscardStagedResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiscardStagedResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiscardStagedResp proto.InternalMessageInfo
+
+func (m *DiscardStagedResp) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *DiscardStagedResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+type SayHelloReq struct {
+	Greet                string   `protobuf:"bytes,1,opt,name=greet,proto3" json:"greet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SayHelloReq) Reset()         { *m = SayHelloReq{} }
+func (m *SayHelloReq) String() string { return proto.CompactTextString(m) }
+func (*SayHelloReq) ProtoMessage()    {}
+func (*SayHelloReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{22}
+}
+func (m *SayHelloReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SayHelloReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SayHelloReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SayHelloReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SayHelloReq.Merge(m, src)
+}
+func (m *SayHelloReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *SayHelloReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SayHelloReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SayHelloReq proto.InternalMessageInfo
+
+func (m *SayHelloReq) GetGreet() string {
+	if m != nil {
+		return m.Greet
+	}
+	return ""
+}
+
+type SayHelloResp struct {
+	Response             string   `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SayHelloResp) Reset()         { *m = SayHelloResp{} }
+func (m *SayHelloResp) String() string { return proto.CompactTextString(m) }
+func (*SayHelloResp) ProtoMessage()    {}
+func (*SayHelloResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5732379159b89c71, []int{23}
+}
+func (m *SayHelloResp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SayHelloResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SayHelloResp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SayHelloResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SayHelloResp.Merge(m, src)
+}
+func (m *SayHelloResp) XXX_Size() int {
+	return m.Size()
+}
+func (m *SayHelloResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_SayHelloResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SayHelloResp proto.InternalMessageInfo
+
+func (m *SayHelloResp) GetResponse() string {
+	if m != nil {
+		return m.Response
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*TableHead)(nil), "service.v1.tableHead")
+	proto.RegisterMapType((map[string]string)(nil), "service.v1.tableHead.DefaultsEntry")
+	proto.RegisterMapType((map[string]*ColumnRule)(nil), "service.v1.tableHead.RulesEntry")
+	proto.RegisterType((*TableReference)(nil), "service.v1.tableReference")
+	proto.RegisterType((*ColumnRule)(nil), "service.v1.columnRule")
+	proto.RegisterType((*TableIndex)(nil), "service.v1.tableIndex")
+	proto.RegisterType((*UpdateConfigReq)(nil), "service.v1.UpdateConfigReq")
+	proto.RegisterType((*UpdateConfigResp)(nil), "service.v1.UpdateConfigResp")
+	proto.RegisterType((*SchemaChange)(nil), "service.v1.SchemaChange")
+	proto.RegisterType((*Violation)(nil), "service.v1.Violation")
+	proto.RegisterType((*SinkStatus)(nil), "service.v1.SinkStatus")
+	proto.RegisterType((*GetConfigReq)(nil), "service.v1.GetConfigReq")
+	proto.RegisterType((*GetConfigResp)(nil), "service.v1.GetConfigResp")
+	proto.RegisterType((*GetSchemaReq)(nil), "service.v1.GetSchemaReq")
+	proto.RegisterType((*GetSchemaResp)(nil), "service.v1.GetSchemaResp")
+	proto.RegisterType((*RollbackConfigReq)(nil), "service.v1.RollbackConfigReq")
+	proto.RegisterType((*RollbackConfigResp)(nil), "service.v1.RollbackConfigResp")
+	proto.RegisterType((*DiffConfigReq)(nil), "service.v1.DiffConfigReq")
+	proto.RegisterType((*DiffConfigResp)(nil), "service.v1.DiffConfigResp")
+	proto.RegisterType((*RowChange)(nil), "service.v1.RowChange")
+	proto.RegisterType((*PublishConfigReq)(nil), "service.v1.PublishConfigReq")
+	proto.RegisterType((*PublishConfigResp)(nil), "service.v1.PublishConfigResp")
+	proto.RegisterType((*DiscardStagedReq)(nil), "service.v1.DiscardStagedReq")
+	proto.RegisterType((*DiscardStagedResp)(nil), "service.v1.DiscardStagedResp")
+	proto.RegisterType((*SayHelloReq)(nil), "service.v1.SayHelloReq")
+	proto.RegisterType((*SayHelloResp)(nil), "service.v1.SayHelloResp")
+}
+
+func init() { proto.RegisterFile("databus.proto", fileDescriptor_5732379159b89c71) }
+
+var fileDescriptor_5732379159b89c71 = []byte{
+	// 1234 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0x4d, 0x6f, 0x1b, 0x45,
+	0x18, 0xee, 0xfa, 0x23, 0x8e, 0x5f, 0xc7, 0x21, 0x19, 0x95, 0xb0, 0x59, 0x1a, 0xcb, 0xda, 0x22,
+	0x94, 0x22, 0x64, 0x41, 0x10, 0xa8, 0xa4, 0x12, 0x95, 0xf2, 0x51, 0x5a, 0x95, 0x42, 0xd8, 0x40,
+	0x0f, 0x9c, 0x18, 0x7b, 0xc7, 0xce, 0xca, 0xeb, 0x99, 0xed, 0xce, 0x3a, 0x8e, 0xc5, 0x4f, 0x80,
+	0x1f, 0x80, 0x38, 0x70, 0xe2, 0xc8, 0x0f, 0xe1, 0xc8, 0x0d, 0x71, 0x41, 0x28, 0xfc, 0x11, 0x34,
+	0x1f, 0xeb, 0x9d, 0x71, 0x6c, 0x57, 0x3d, 0x20, 0x4e, 0xd9, 0xe7, 0xfd, 0xda, 0x77, 0xe6, 0x7d,
+	0x9e, 0x77, 0x63, 0x68, 0x86, 0x38, 0xc3, 0xdd, 0x31, 0xef, 0x24, 0x29, 0xcb, 0x18, 0x02, 0x4e,
+	0xd2, 0xcb, 0xa8, 0x47, 0x3a, 0x97, 0xef, 0xfb, 0x7f, 0x54, 0xa0, 0x9e, 0xe1, 0x6e, 0x4c, 0x1e,
+	0x13, 0x1c, 0xa2, 0x1d, 0x58, 0xeb, 0x47, 0x24, 0x0e, 0xb9, 0xeb, 0xb4, 0xcb, 0xfb, 0xf5, 0x40,
+	0x23, 0x74, 0x1b, 0xaa, 0xd9, 0x34, 0x21, 0xdc, 0x2d, 0x49, 0xb3, 0x02, 0xc2, 0x1a, 0x12, 0xde,
+	0xe3, 0x6e, 0x59, 0x59, 0x25, 0x40, 0x2d, 0x80, 0x24, 0x8d, 0x46, 0x38, 0x9d, 0x3e, 0x25, 0x53,
+	0xb7, 0x22, 0x5d, 0x86, 0x05, 0xf9, 0xb0, 0x41, 0xd9, 0x59, 0x11, 0x51, 0x6d, 0x3b, 0xfb, 0xeb,
+	0x81, 0x65, 0x43, 0xef, 0x41, 0x6d, 0x4c, 0xa3, 0x17, 0x63, 0xc2, 0xdd, 0xb5, 0x76, 0x79, 0xbf,
+	0x71, 0xb0, 0xd3, 0x29, 0x7a, 0xee, 0xc8, 0x7e, 0x9f, 0xd0, 0x90, 0x5c, 0x05, 0x79, 0x98, 0xc8,
+	0x88, 0x84, 0x85, 0x70, 0xb7, 0xb6, 0x3a, 0x43, 0x87, 0x21, 0x0f, 0xd6, 0xe9, 0x38, 0x8e, 0x85,
+	0xc7, 0x5d, 0x97, 0x5d, 0xce, 0x30, 0x7a, 0x08, 0xeb, 0x21, 0xe9, 0xe3, 0x71, 0x9c, 0x71, 0xb7,
+	0x2e, 0xcb, 0xdd, 0xbd, 0x51, 0x4e, 0x5c, 0x58, 0xe7, 0x44, 0x47, 0x9d, 0xd2, 0x2c, 0x9d, 0x06,
+	0xb3, 0x24, 0xf4, 0x11, 0x54, 0xd3, 0x71, 0x4c, 0xb8, 0x0b, 0x32, 0xbb, 0xbd, 0x38, 0x3b, 0x10,
+	0x21, 0x2a, 0x55, 0x85, 0xa3, 0x43, 0x80, 0x94, 0xf4, 0x49, 0x4a, 0x68, 0x8f, 0x70, 0xb7, 0x21,
+	0x93, 0xbd, 0x1b, 0xc9, 0x41, 0x1e, 0x12, 0x18, 0xd1, 0xde, 0x03, 0x68, 0x5a, 0xed, 0xa0, 0x2d,
+	0x28, 0x0f, 0xc9, 0xd4, 0x75, 0xda, 0xce, 0x7e, 0x3d, 0x10, 0x8f, 0x62, 0x62, 0x97, 0x38, 0x1e,
+	0x13, 0xb7, 0x24, 0x6d, 0x0a, 0x1c, 0x96, 0xee, 0x3b, 0xde, 0x19, 0x40, 0xd1, 0xcd, 0x82, 0xcc,
+	0x77, 0xcd, 0xcc, 0xb9, 0xdb, 0xed, 0xb1, 0x78, 0x3c, 0xa2, 0x22, 0xdd, 0xa8, 0xe8, 0x7f, 0x0b,
+	0x9b, 0x76, 0xb3, 0x2b, 0xd9, 0x25, 0xc7, 0xa0, 0xbb, 0x92, 0x00, 0xb5, 0xa1, 0x21, 0x1f, 0x1e,
+	0xa9, 0x14, 0xc5, 0x31, 0xd3, 0xe4, 0xff, 0xe4, 0x00, 0x14, 0xef, 0x16, 0x4d, 0x8f, 0x22, 0x9a,
+	0x37, 0x3d, 0x8a, 0xa8, 0xb4, 0xe0, 0x2b, 0x5d, 0x56, 0x3c, 0x22, 0x17, 0x6a, 0x09, 0xce, 0x32,
+	0x92, 0x52, 0xb7, 0x2c, 0xad, 0x39, 0x44, 0x77, 0xa0, 0x3e, 0xc2, 0x57, 0x9f, 0x11, 0x3a, 0xc8,
+	0x2e, 0xdc, 0x4a, 0xdb, 0xd9, 0xaf, 0x06, 0x85, 0x41, 0xb4, 0xc8, 0x28, 0xf9, 0xa2, 0xef, 0x56,
+	0x15, 0xd5, 0x25, 0x90, 0x14, 0x62, 0xf4, 0x74, 0x94, 0x64, 0x53, 0x77, 0x4d, 0xd2, 0x78, 0x86,
+	0xfd, 0xfb, 0x00, 0x05, 0xeb, 0x10, 0x82, 0x0a, 0xc5, 0x23, 0xa2, 0x9b, 0x93, 0xcf, 0xc6, 0x75,
+	0x94, 0xcc, 0xeb, 0xf0, 0xff, 0x72, 0xe0, 0xb5, 0xaf, 0x93, 0x10, 0x67, 0xe4, 0x98, 0xd1, 0x7e,
+	0x34, 0x08, 0xc8, 0x8b, 0x85, 0xf9, 0xf7, 0xa0, 0x72, 0x41, 0x70, 0xa8, 0x27, 0xf2, 0xfa, 0x42,
+	0x8a, 0x05, 0x32, 0x44, 0x1c, 0xbb, 0xc7, 0x68, 0x46, 0x68, 0x96, 0x1f, 0x5b, 0x43, 0xa1, 0xd6,
+	0x30, 0xa2, 0x83, 0x0c, 0xc7, 0xc3, 0x27, 0x27, 0xf2, 0xdc, 0xf5, 0xc0, 0xb0, 0xa0, 0xb7, 0xa0,
+	0x89, 0xe3, 0x98, 0x4d, 0x8e, 0x52, 0x82, 0x87, 0x11, 0x1d, 0x68, 0xb9, 0xda, 0x46, 0x71, 0x94,
+	0x30, 0x9d, 0x06, 0x63, 0xaa, 0xaf, 0x41, 0x23, 0x71, 0x6d, 0x3c, 0xc3, 0x03, 0xe2, 0xd6, 0xa4,
+	0x59, 0x01, 0xff, 0xd7, 0x12, 0x6c, 0xd9, 0x07, 0xe4, 0x89, 0x28, 0xc1, 0x33, 0x9c, 0x8d, 0xb9,
+	0x3c, 0x63, 0x35, 0xd0, 0x48, 0xd8, 0x49, 0x9a, 0x3e, 0xe3, 0x03, 0x3d, 0x46, 0x8d, 0x04, 0x21,
+	0x79, 0x44, 0x87, 0x8a, 0x18, 0x73, 0x84, 0x3c, 0x8f, 0xe8, 0xf0, 0x5c, 0xa6, 0x07, 0x2a, 0x08,
+	0x7d, 0x08, 0x70, 0x19, 0xb1, 0x18, 0x67, 0x11, 0xa3, 0x5c, 0x2e, 0xa5, 0xb9, 0x1b, 0x7b, 0x9e,
+	0x7b, 0x03, 0x23, 0x10, 0x7d, 0x02, 0x4d, 0xde, 0xbb, 0x20, 0x23, 0x7c, 0x7c, 0x81, 0xe9, 0x80,
+	0x70, 0x39, 0xfe, 0xc6, 0x81, 0x6b, 0xbd, 0xcc, 0x08, 0x08, 0xec, 0x70, 0x71, 0xef, 0x97, 0x24,
+	0xe5, 0x11, 0x53, 0x17, 0x53, 0x0e, 0x72, 0x28, 0x86, 0x97, 0xb2, 0x49, 0xbe, 0xac, 0xac, 0x56,
+	0x02, 0x36, 0xd1, 0xd5, 0x64, 0x88, 0xff, 0x8b, 0x03, 0x1b, 0xe6, 0x4b, 0x04, 0x19, 0x86, 0x11,
+	0x0d, 0x73, 0x32, 0x88, 0x67, 0x71, 0xd3, 0x92, 0x3e, 0xb9, 0x86, 0x24, 0x10, 0x04, 0x65, 0x71,
+	0x28, 0xe5, 0xa2, 0x07, 0x3f, 0xc3, 0xa2, 0x37, 0x16, 0x87, 0x5f, 0x4d, 0x13, 0xa2, 0xc7, 0x9e,
+	0x43, 0xe1, 0xa1, 0x64, 0x22, 0x3d, 0x55, 0xe5, 0xd1, 0x50, 0xd4, 0xeb, 0xe6, 0x44, 0xd0, 0x84,
+	0xcf, 0xb1, 0xff, 0x0c, 0xea, 0xb3, 0x4b, 0x14, 0xca, 0x4b, 0xd9, 0x44, 0x8f, 0x52, 0x3c, 0x8a,
+	0x39, 0x2a, 0xad, 0xe6, 0x73, 0x54, 0x48, 0xd8, 0x53, 0x82, 0x39, 0xcb, 0x05, 0xa9, 0x91, 0x7f,
+	0x06, 0x50, 0x8c, 0x71, 0x99, 0x7e, 0x34, 0x63, 0x4a, 0x4b, 0x18, 0x53, 0x36, 0x19, 0xe3, 0xfb,
+	0xb0, 0xf1, 0x29, 0xc9, 0x56, 0x6a, 0xca, 0xbf, 0x07, 0x4d, 0x23, 0x86, 0x27, 0xa6, 0x72, 0x1c,
+	0x4b, 0x39, 0xba, 0x9c, 0x1a, 0xcc, 0xb2, 0x72, 0x87, 0xd0, 0x34, 0x62, 0x78, 0x32, 0xd3, 0xac,
+	0xf3, 0x52, 0xcd, 0xfa, 0x18, 0xb6, 0x03, 0x16, 0xc7, 0x5d, 0xdc, 0x1b, 0xae, 0xde, 0x03, 0x06,
+	0xc9, 0x4a, 0x36, 0xc9, 0x6c, 0x71, 0x97, 0xe7, 0xc5, 0xed, 0xff, 0xe0, 0x00, 0x9a, 0x7f, 0xc7,
+	0x7f, 0x2e, 0x45, 0xa3, 0xdd, 0x8a, 0xd5, 0xae, 0xff, 0xb3, 0x03, 0xcd, 0x93, 0xa8, 0xdf, 0x5f,
+	0x7d, 0xdc, 0x36, 0x34, 0xfa, 0x29, 0x1b, 0x3d, 0xb7, 0x8e, 0x6c, 0x9a, 0xc4, 0x2a, 0xcf, 0x58,
+	0xee, 0x2f, 0x4b, 0x7f, 0x61, 0x40, 0x1f, 0x43, 0xbd, 0x87, 0x69, 0x18, 0x89, 0xfd, 0x23, 0x3b,
+	0x68, 0x1c, 0xbc, 0x69, 0x76, 0x3c, 0xb7, 0x7a, 0x83, 0x22, 0xda, 0xff, 0x0e, 0x36, 0xcd, 0xfe,
+	0xd4, 0x3c, 0xa5, 0x8c, 0x9d, 0x97, 0xca, 0xf8, 0xe6, 0x2e, 0x29, 0xbd, 0xd2, 0x2e, 0xf1, 0xa7,
+	0x50, 0x9f, 0x95, 0x5c, 0xb8, 0x02, 0xf4, 0x47, 0xbb, 0x54, 0x7c, 0xb4, 0x77, 0x60, 0x8d, 0xc5,
+	0x61, 0xc0, 0x26, 0xb9, 0x12, 0x14, 0x12, 0x76, 0x4a, 0x26, 0xc2, 0xae, 0x94, 0xaf, 0x91, 0xf1,
+	0x45, 0xaa, 0x5a, 0x5f, 0xa4, 0x47, 0xb0, 0x75, 0x36, 0xee, 0xc6, 0x11, 0xbf, 0x58, 0x3d, 0x1a,
+	0x9b, 0x6f, 0xa5, 0x1b, 0x7c, 0xfb, 0xde, 0x81, 0xed, 0xb9, 0x42, 0xff, 0x23, 0xdd, 0xde, 0x86,
+	0xad, 0x93, 0x88, 0xf7, 0x70, 0x1a, 0x9e, 0x8b, 0xcf, 0x52, 0xb8, 0x4c, 0xc4, 0xc7, 0xb0, 0x3d,
+	0x17, 0xf7, 0xea, 0x4d, 0xfb, 0x77, 0xa1, 0x71, 0x8e, 0xa7, 0x8f, 0x49, 0x1c, 0x33, 0xf1, 0x9e,
+	0xdb, 0x50, 0x1d, 0xa4, 0x84, 0xe4, 0x4b, 0x45, 0x01, 0xff, 0x1d, 0xd8, 0x28, 0x82, 0x78, 0x22,
+	0xd6, 0x6d, 0x4a, 0x78, 0xc2, 0x28, 0xcf, 0x3b, 0x9a, 0xe1, 0x83, 0x3f, 0x2b, 0x50, 0x3b, 0x51,
+	0xff, 0xd6, 0xa3, 0xa7, 0xb0, 0x61, 0xb2, 0x16, 0xad, 0xe2, 0xb3, 0x77, 0x67, 0xb9, 0x93, 0x27,
+	0xfe, 0x2d, 0x74, 0x04, 0xf5, 0xd9, 0x0a, 0x44, 0x16, 0x3b, 0xcd, 0xed, 0xe9, 0xed, 0x2e, 0xf1,
+	0x18, 0x35, 0x14, 0x9b, 0x6f, 0xd4, 0x98, 0xad, 0x4c, 0x6f, 0x77, 0x89, 0x47, 0xd6, 0xf8, 0x12,
+	0x36, 0xed, 0xdd, 0x84, 0xf6, 0x6c, 0x79, 0xcd, 0xed, 0x46, 0xaf, 0xb5, 0xca, 0x2d, 0x4b, 0x9e,
+	0x02, 0x14, 0xfa, 0x45, 0xd6, 0xdb, 0xad, 0xbd, 0xe3, 0x79, 0xcb, 0x5c, 0xb2, 0xcc, 0xe7, 0xd0,
+	0xb4, 0x58, 0x8c, 0xac, 0x2b, 0x9d, 0x57, 0x8a, 0xb7, 0xb7, 0xc2, 0x9b, 0xd7, 0xb3, 0x08, 0x66,
+	0xd7, 0x9b, 0xe7, 0xa8, 0xb7, 0xb7, 0xc2, 0x2b, 0xeb, 0x3d, 0x84, 0xf5, 0x9c, 0x46, 0xe8, 0x0d,
+	0x4b, 0x1d, 0x05, 0x03, 0x3d, 0x77, 0xb1, 0x43, 0x14, 0x38, 0xda, 0xfd, 0xed, 0xba, 0xe5, 0xfc,
+	0x7e, 0xdd, 0x72, 0xfe, 0xbe, 0x6e, 0x39, 0x3f, 0xfe, 0xd3, 0xba, 0xf5, 0x4d, 0xad, 0xf3, 0x40,
+	0xfe, 0x76, 0xec, 0xae, 0xc9, 0x3f, 0x1f, 0xfc, 0x3b, 0x00, 0xab, 0x76, 0xf4, 0xd3, 0x53, 0x0e,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DatabusClient is the client API for Databus service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DatabusClient interface {
+	UpdateConfig(ctx context.Context, in *UpdateConfigReq, opts ...grpc.CallOption) (*UpdateConfigResp, error)
+	GetConfig(ctx context.Context, in *GetConfigReq, opts ...grpc.CallOption) (*GetConfigResp, error)
+	GetSchema(ctx context.Context, in *GetSchemaReq, opts ...grpc.CallOption) (*GetSchemaResp, error)
+	RollbackConfig(ctx context.Context, in *RollbackConfigReq, opts ...grpc.CallOption) (*RollbackConfigResp, error)
+	DiffConfig(ctx context.Context, in *DiffConfigReq, opts ...grpc.CallOption) (*DiffConfigResp, error)
+	PublishConfig(ctx context.Context, in *PublishConfigReq, opts ...grpc.CallOption) (*PublishConfigResp, error)
+	DiscardStaged(ctx context.Context, in *DiscardStagedReq, opts ...grpc.CallOption) (*DiscardStagedResp, error)
+	SayHello(ctx context.Context, in *SayHelloReq, opts ...grpc.CallOption) (*SayHelloResp, error)
+}
+
+type databusClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDatabusClient(cc *grpc.ClientConn) DatabusClient {
+	return &databusClient{cc}
+}
+
+func (c *databusClient) UpdateConfig(ctx context.Context, in *UpdateConfigReq, opts ...grpc.CallOption) (*UpdateConfigResp, error) {
+	out := new(UpdateConfigResp)
+	err := c.cc.Invoke(ctx, "/service.v1.Databus/UpdateConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databusClient) GetConfig(ctx context.Context, in *GetConfigReq, opts ...grpc.CallOption) (*GetConfigResp, error) {
+	out := new(GetConfigResp)
+	err := c.cc.Invoke(ctx, "/service.v1.Databus/GetConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databusClient) GetSchema(ctx context.Context, in *GetSchemaReq, opts ...grpc.CallOption) (*GetSchemaResp, error) {
+	out := new(GetSchemaResp)
+	err := c.cc.Invoke(ctx, "/service.v1.Databus/GetSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databusClient) RollbackConfig(ctx context.Context, in *RollbackConfigReq, opts ...grpc.CallOption) (*RollbackConfigResp, error) {
+	out := new(RollbackConfigResp)
+	err := c.cc.Invoke(ctx, "/service.v1.Databus/RollbackConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databusClient) DiffConfig(ctx context.Context, in *DiffConfigReq, opts ...grpc.CallOption) (*DiffConfigResp, error) {
+	out := new(DiffConfigResp)
+	err := c.cc.Invoke(ctx, "/service.v1.Databus/DiffConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databusClient) PublishConfig(ctx context.Context, in *PublishConfigReq, opts ...grpc.CallOption) (*PublishConfigResp, error) {
+	out := new(PublishConfigResp)
+	err := c.cc.Invoke(ctx, "/service.v1.Databus/PublishConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databusClient) DiscardStaged(ctx context.Context, in *DiscardStagedReq, opts ...grpc.CallOption) (*DiscardStagedResp, error) {
+	out := new(DiscardStagedResp)
+	err := c.cc.Invoke(ctx, "/service.v1.Databus/DiscardStaged", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databusClient) SayHello(ctx context.Context, in *SayHelloReq, opts ...grpc.CallOption) (*SayHelloResp, error) {
+	out := new(SayHelloResp)
+	err := c.cc.Invoke(ctx, "/service.v1.Databus/SayHello", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DatabusServer is the server API for Databus service.
+type DatabusServer interface {
+	UpdateConfig(context.Context, *UpdateConfigReq) (*UpdateConfigResp, error)
+	GetConfig(context.Context, *GetConfigReq) (*GetConfigResp, error)
+	GetSchema(context.Context, *GetSchemaReq) (*GetSchemaResp, error)
+	RollbackConfig(context.Context, *RollbackConfigReq) (*RollbackConfigResp, error)
+	DiffConfig(context.Context, *DiffConfigReq) (*DiffConfigResp, error)
+	PublishConfig(context.Context, *PublishConfigReq) (*PublishConfigResp, error)
+	DiscardStaged(context.Context, *DiscardStagedReq) (*DiscardStagedResp, error)
+	SayHello(context.Context, *SayHelloReq) (*SayHelloResp, error)
+}
+
+// UnimplementedDatabusServer can be embedded to have forward compatible implementations.
+type UnimplementedDatabusServer struct {
+}
+
+func (*UnimplementedDatabusServer) UpdateConfig(ctx context.Context, req *UpdateConfigReq) (*UpdateConfigResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfig not implemented")
+}
+func (*UnimplementedDatabusServer) GetConfig(ctx context.Context, req *GetConfigReq) (*GetConfigResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (*UnimplementedDatabusServer) GetSchema(ctx context.Context, req *GetSchemaReq) (*GetSchemaResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchema not implemented")
+}
+func (*UnimplementedDatabusServer) RollbackConfig(ctx context.Context, req *RollbackConfigReq) (*RollbackConfigResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackConfig not implemented")
+}
+func (*UnimplementedDatabusServer) DiffConfig(ctx context.Context, req *DiffConfigReq) (*DiffConfigResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffConfig not implemented")
+}
+func (*UnimplementedDatabusServer) PublishConfig(ctx context.Context, req *PublishConfigReq) (*PublishConfigResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishConfig not implemented")
+}
+func (*UnimplementedDatabusServer) DiscardStaged(ctx context.Context, req *DiscardStagedReq) (*DiscardStagedResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscardStaged not implemented")
+}
+func (*UnimplementedDatabusServer) SayHello(ctx context.Context, req *SayHelloReq) (*SayHelloResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SayHello not implemented")
+}
+
+func RegisterDatabusServer(s *grpc.Server, srv DatabusServer) {
+	s.RegisterService(&_Databus_serviceDesc, srv)
+}
+
+func _Databus_UpdateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConfigReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabusServer).UpdateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.v1.Databus/UpdateConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabusServer).UpdateConfig(ctx, req.(*UpdateConfigReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Databus_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabusServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.v1.Databus/GetConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabusServer).GetConfig(ctx, req.(*GetConfigReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Databus_GetSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchemaReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabusServer).GetSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.v1.Databus/GetSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabusServer).GetSchema(ctx, req.(*GetSchemaReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Databus_RollbackConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackConfigReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabusServer).RollbackConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.v1.Databus/RollbackConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabusServer).RollbackConfig(ctx, req.(*RollbackConfigReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Databus_DiffConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffConfigReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabusServer).DiffConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.v1.Databus/DiffConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabusServer).DiffConfig(ctx, req.(*DiffConfigReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Databus_PublishConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishConfigReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabusServer).PublishConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.v1.Databus/PublishConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabusServer).PublishConfig(ctx, req.(*PublishConfigReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Databus_DiscardStaged_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardStagedReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabusServer).DiscardStaged(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.v1.Databus/DiscardStaged",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabusServer).DiscardStaged(ctx, req.(*DiscardStagedReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Databus_SayHello_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SayHelloReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabusServer).SayHello(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.v1.Databus/SayHello",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabusServer).SayHello(ctx, req.(*SayHelloReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Databus_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "service.v1.Databus",
+	HandlerType: (*DatabusServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpdateConfig",
+			Handler:    _Databus_UpdateConfig_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _Databus_GetConfig_Handler,
+		},
+		{
+			MethodName: "GetSchema",
+			Handler:    _Databus_GetSchema_Handler,
+		},
+		{
+			MethodName: "RollbackConfig",
+			Handler:    _Databus_RollbackConfig_Handler,
+		},
+		{
+			MethodName: "DiffConfig",
+			Handler:    _Databus_DiffConfig_Handler,
+		},
+		{
+			MethodName: "PublishConfig",
+			Handler:    _Databus_PublishConfig_Handler,
+		},
+		{
+			MethodName: "DiscardStaged",
+			Handler:    _Databus_DiscardStaged_Handler,
+		},
+		{
+			MethodName: "SayHello",
+			Handler:    _Databus_SayHello_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "databus.proto",
+}
+
+func (m *TableHead) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TableHead) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TableHead) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.References) > 0 {
+		for iNdEx := len(m.References) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.References[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDatabus(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if len(m.Rules) > 0 {
+		for k := range m.Rules {
+			v := m.Rules[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintDatabus(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintDatabus(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintDatabus(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.Defaults) > 0 {
+		for k := range m.Defaults {
+			v := m.Defaults[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintDatabus(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintDatabus(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintDatabus(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.Nullable) > 0 {
+		for iNdEx := len(m.Nullable) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Nullable[iNdEx])
+			copy(dAtA[i:], m.Nullable[iNdEx])
+			i = encodeVarintDatabus(dAtA, i, uint64(len(m.Nullable[iNdEx])))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.Indexes) > 0 {
+		for iNdEx := len(m.Indexes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Indexes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDatabus(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.Uniques) > 0 {
+		for iNdEx := len(m.Uniques) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Uniques[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDatabus(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.NoPrimaryKey {
+		i--
+		if m.NoPrimaryKey {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.PrimaryKey) > 0 {
+		for iNdEx := len(m.PrimaryKey) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PrimaryKey[iNdEx])
+			copy(dAtA[i:], m.PrimaryKey[iNdEx])
+			i = encodeVarintDatabus(dAtA, i, uint64(len(m.PrimaryKey[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Descs) > 0 {
+		for iNdEx := len(m.Descs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Descs[iNdEx])
+			copy(dAtA[i:], m.Descs[iNdEx])
+			i = encodeVarintDatabus(dAtA, i, uint64(len(m.Descs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Types) > 0 {
+		for iNdEx := len(m.Types) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Types[iNdEx])
+			copy(dAtA[i:], m.Types[iNdEx])
+			i = encodeVarintDatabus(dAtA, i, uint64(len(m.Types[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Fields) > 0 {
+		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Fields[iNdEx])
+			copy(dAtA[i:], m.Fields[iNdEx])
+			i = encodeVarintDatabus(dAtA, i, uint64(len(m.Fields[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TableReference) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TableReference) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TableReference) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.TableFields) > 0 {
+		for iNdEx := len(m.TableFields) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TableFields[iNdEx])
+			copy(dAtA[i:], m.TableFields[iNdEx])
+			i = encodeVarintDatabus(dAtA, i, uint64(len(m.TableFields[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Table) > 0 {
+		i -= len(m.Table)
+		copy(dAtA[i:], m.Table)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Table)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Fields) > 0 {
+		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Fields[iNdEx])
+			copy(dAtA[i:], m.Fields[iNdEx])
+			i = encodeVarintDatabus(dAtA, i, uint64(len(m.Fields[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ColumnRule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ColumnRule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ColumnRule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.NonEmpty {
+		i--
+		if m.NonEmpty {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.OneOf) > 0 {
+		for iNdEx := len(m.OneOf) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.OneOf[iNdEx])
+			copy(dAtA[i:], m.OneOf[iNdEx])
+			i = encodeVarintDatabus(dAtA, i, uint64(len(m.OneOf[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.MaxLength != 0 {
+		i = encodeVarintDatabus(dAtA, i, uint64(m.MaxLength))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Pattern) > 0 {
+		i -= len(m.Pattern)
+		copy(dAtA[i:], m.Pattern)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Pattern)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Max) > 0 {
+		i -= len(m.Max)
+		copy(dAtA[i:], m.Max)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Max)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Min) > 0 {
+		i -= len(m.Min)
+		copy(dAtA[i:], m.Min)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Min)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TableIndex) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TableIndex) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TableIndex) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Fields) > 0 {
+		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Fields[iNdEx])
+			copy(dAtA[i:], m.Fields[iNdEx])
+			i = encodeVarintDatabus(dAtA, i, uint64(len(m.Fields[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdateConfigReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateConfigReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateConfigReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Stage {
+		i--
+		if m.Stage {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.DryRun {
+		i--
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.AllowBreaking {
+		i--
+		if m.AllowBreaking {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.DingtalkID) > 0 {
+		i -= len(m.DingtalkID)
+		copy(dAtA[i:], m.DingtalkID)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.DingtalkID)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Content) > 0 {
+		i -= len(m.Content)
+		copy(dAtA[i:], m.Content)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Content)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Head != nil {
+		{
+			size, err := m.Head.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDatabus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdateConfigResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateConfigResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateConfigResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Rows) > 0 {
+		for iNdEx := len(m.Rows) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Rows[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDatabus(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.Version != 0 {
+		i = encodeVarintDatabus(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.SchemaChanges) > 0 {
+		for iNdEx := len(m.SchemaChanges) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SchemaChanges[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDatabus(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Violations) > 0 {
+		for iNdEx := len(m.Violations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Violations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDatabus(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Sinks) > 0 {
+		for iNdEx := len(m.Sinks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Sinks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDatabus(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.ErrMsg) > 0 {
+		i -= len(m.ErrMsg)
+		copy(dAtA[i:], m.ErrMsg)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.ErrMsg)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Status != 0 {
+		i = encodeVarintDatabus(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SchemaChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SchemaChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SchemaChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Breaking {
+		i--
+		if m.Breaking {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.NewType) > 0 {
+		i -= len(m.NewType)
+		copy(dAtA[i:], m.NewType)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.NewType)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.OldType) > 0 {
+		i -= len(m.OldType)
+		copy(dAtA[i:], m.OldType)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.OldType)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.OldField) > 0 {
+		i -= len(m.OldField)
+		copy(dAtA[i:], m.OldField)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.OldField)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Kind) > 0 {
+		i -= len(m.Kind)
+		copy(dAtA[i:], m.Kind)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Kind)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Violation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Violation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Violation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Column) > 0 {
+		i -= len(m.Column)
+		copy(dAtA[i:], m.Column)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Column)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Row != 0 {
+		i = encodeVarintDatabus(dAtA, i, uint64(m.Row))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SinkStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SinkStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SinkStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ErrMsg) > 0 {
+		i -= len(m.ErrMsg)
+		copy(dAtA[i:], m.ErrMsg)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.ErrMsg)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Status != 0 {
+		i = encodeVarintDatabus(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetConfigReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetConfigReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetConfigReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetConfigResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetConfigResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetConfigResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Content) > 0 {
+		i -= len(m.Content)
+		copy(dAtA[i:], m.Content)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Content)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetSchemaReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetSchemaReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetSchemaReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetSchemaResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetSchemaResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetSchemaResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Head != nil {
+		{
+			size, err := m.Head.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDatabus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RollbackConfigReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RollbackConfigReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RollbackConfigReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.DingtalkID) > 0 {
+		i -= len(m.DingtalkID)
+		copy(dAtA[i:], m.DingtalkID)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.DingtalkID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Version != 0 {
+		i = encodeVarintDatabus(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RollbackConfigResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RollbackConfigResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RollbackConfigResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintDatabus(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Sinks) > 0 {
+		for iNdEx := len(m.Sinks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Sinks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDatabus(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.ErrMsg) > 0 {
+		i -= len(m.ErrMsg)
+		copy(dAtA[i:], m.ErrMsg)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.ErrMsg)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Status != 0 {
+		i = encodeVarintDatabus(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiffConfigReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiffConfigReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiffConfigReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Candidate != nil {
+		{
+			size, err := m.Candidate.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDatabus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.ToVersion != 0 {
+		i = encodeVarintDatabus(dAtA, i, uint64(m.ToVersion))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.FromVersion != 0 {
+		i = encodeVarintDatabus(dAtA, i, uint64(m.FromVersion))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiffConfigResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiffConfigResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiffConfigResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.SchemaChanges) > 0 {
+		for iNdEx := len(m.SchemaChanges) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SchemaChanges[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDatabus(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Rows) > 0 {
+		for iNdEx := len(m.Rows) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Rows[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDatabus(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RowChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RowChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RowChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Fields) > 0 {
+		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Fields[iNdEx])
+			copy(dAtA[i:], m.Fields[iNdEx])
+			i = encodeVarintDatabus(dAtA, i, uint64(len(m.Fields[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.NewRow) > 0 {
+		i -= len(m.NewRow)
+		copy(dAtA[i:], m.NewRow)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.NewRow)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.OldRow) > 0 {
+		i -= len(m.OldRow)
+		copy(dAtA[i:], m.OldRow)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.OldRow)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Kind) > 0 {
+		i -= len(m.Kind)
+		copy(dAtA[i:], m.Kind)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Kind)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PublishConfigReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PublishConfigReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PublishConfigReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.DingtalkID) > 0 {
+		i -= len(m.DingtalkID)
+		copy(dAtA[i:], m.DingtalkID)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.DingtalkID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PublishConfigResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PublishConfigResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PublishConfigResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintDatabus(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Sinks) > 0 {
+		for iNdEx := len(m.Sinks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Sinks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDatabus(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.ErrMsg) > 0 {
+		i -= len(m.ErrMsg)
+		copy(dAtA[i:], m.ErrMsg)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.ErrMsg)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Status != 0 {
+		i = encodeVarintDatabus(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiscardStagedReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiscardStagedReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiscardStagedReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiscardStagedResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiscardStagedResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiscardStagedResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ErrMsg) > 0 {
+		i -= len(m.ErrMsg)
+		copy(dAtA[i:], m.ErrMsg)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.ErrMsg)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Status != 0 {
+		i = encodeVarintDatabus(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SayHelloReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SayHelloReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SayHelloReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Greet) > 0 {
+		i -= len(m.Greet)
+		copy(dAtA[i:], m.Greet)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Greet)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SayHelloResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SayHelloResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SayHelloResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Response) > 0 {
+		i -= len(m.Response)
+		copy(dAtA[i:], m.Response)
+		i = encodeVarintDatabus(dAtA, i, uint64(len(m.Response)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintDatabus(dAtA []byte, offset int, v uint64) int {
+	offset -= sovDatabus(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *TableHead) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Fields) > 0 {
+		for _, s := range m.Fields {
+			l = len(s)
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if len(m.Types) > 0 {
+		for _, s := range m.Types {
+			l = len(s)
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if len(m.Descs) > 0 {
+		for _, s := range m.Descs {
+			l = len(s)
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if len(m.PrimaryKey) > 0 {
+		for _, s := range m.PrimaryKey {
+			l = len(s)
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if m.NoPrimaryKey {
+		n += 2
+	}
+	if len(m.Uniques) > 0 {
+		for _, e := range m.Uniques {
+			l = e.Size()
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if len(m.Indexes) > 0 {
+		for _, e := range m.Indexes {
+			l = e.Size()
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if len(m.Nullable) > 0 {
+		for _, s := range m.Nullable {
+			l = len(s)
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if len(m.Defaults) > 0 {
+		for k, v := range m.Defaults {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovDatabus(uint64(len(k))) + 1 + len(v) + sovDatabus(uint64(len(v)))
+			n += mapEntrySize + 1 + sovDatabus(uint64(mapEntrySize))
+		}
+	}
+	if len(m.Rules) > 0 {
+		for k, v := range m.Rules {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovDatabus(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovDatabus(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovDatabus(uint64(mapEntrySize))
+		}
+	}
+	if len(m.References) > 0 {
+		for _, e := range m.References {
+			l = e.Size()
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TableReference) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Fields) > 0 {
+		for _, s := range m.Fields {
+			l = len(s)
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	l = len(m.Table)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if len(m.TableFields) > 0 {
+		for _, s := range m.TableFields {
+			l = len(s)
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ColumnRule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Min)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	l = len(m.Max)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	l = len(m.Pattern)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.MaxLength != 0 {
+		n += 1 + sovDatabus(uint64(m.MaxLength))
+	}
+	if len(m.OneOf) > 0 {
+		for _, s := range m.OneOf {
+			l = len(s)
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if m.NonEmpty {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TableIndex) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if len(m.Fields) > 0 {
+		for _, s := range m.Fields {
+			l = len(s)
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateConfigReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.Head != nil {
+		l = m.Head.Size()
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	l = len(m.Content)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	l = len(m.DingtalkID)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.AllowBreaking {
+		n += 2
+	}
+	if m.DryRun {
+		n += 2
+	}
+	if m.Stage {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateConfigResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovDatabus(uint64(m.Status))
+	}
+	l = len(m.ErrMsg)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if len(m.Sinks) > 0 {
+		for _, e := range m.Sinks {
+			l = e.Size()
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if len(m.Violations) > 0 {
+		for _, e := range m.Violations {
+			l = e.Size()
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if len(m.SchemaChanges) > 0 {
+		for _, e := range m.SchemaChanges {
+			l = e.Size()
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if m.Version != 0 {
+		n += 1 + sovDatabus(uint64(m.Version))
+	}
+	if len(m.Rows) > 0 {
+		for _, e := range m.Rows {
+			l = e.Size()
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SchemaChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Kind)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	l = len(m.OldField)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	l = len(m.OldType)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	l = len(m.NewType)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.Breaking {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Violation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Row != 0 {
+		n += 1 + sovDatabus(uint64(m.Row))
+	}
+	l = len(m.Column)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SinkStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.Status != 0 {
+		n += 1 + sovDatabus(uint64(m.Status))
+	}
+	l = len(m.ErrMsg)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetConfigReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetConfigResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Content)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetSchemaReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetSchemaResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Head != nil {
+		l = m.Head.Size()
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RollbackConfigReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovDatabus(uint64(m.Version))
+	}
+	l = len(m.DingtalkID)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RollbackConfigResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovDatabus(uint64(m.Status))
+	}
+	l = len(m.ErrMsg)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if len(m.Sinks) > 0 {
+		for _, e := range m.Sinks {
+			l = e.Size()
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if m.Version != 0 {
+		n += 1 + sovDatabus(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiffConfigReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.FromVersion != 0 {
+		n += 1 + sovDatabus(uint64(m.FromVersion))
+	}
+	if m.ToVersion != 0 {
+		n += 1 + sovDatabus(uint64(m.ToVersion))
+	}
+	if m.Candidate != nil {
+		l = m.Candidate.Size()
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiffConfigResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Rows) > 0 {
+		for _, e := range m.Rows {
+			l = e.Size()
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if len(m.SchemaChanges) > 0 {
+		for _, e := range m.SchemaChanges {
+			l = e.Size()
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RowChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Kind)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	l = len(m.OldRow)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	l = len(m.NewRow)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if len(m.Fields) > 0 {
+		for _, s := range m.Fields {
+			l = len(s)
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PublishConfigReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	l = len(m.DingtalkID)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PublishConfigResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovDatabus(uint64(m.Status))
+	}
+	l = len(m.ErrMsg)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if len(m.Sinks) > 0 {
+		for _, e := range m.Sinks {
+			l = e.Size()
+			n += 1 + l + sovDatabus(uint64(l))
+		}
+	}
+	if m.Version != 0 {
+		n += 1 + sovDatabus(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiscardStagedReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiscardStagedResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovDatabus(uint64(m.Status))
+	}
+	l = len(m.ErrMsg)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SayHelloReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Greet)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SayHelloResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Response)
+	if l > 0 {
+		n += 1 + l + sovDatabus(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovDatabus(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozDatabus(x uint64) (n int) {
+	return sovDatabus(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *TableHead) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: tableHead: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: tableHead: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = append(m.Fields, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Types", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Types = append(m.Types, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Descs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Descs = append(m.Descs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrimaryKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrimaryKey = append(m.PrimaryKey, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NoPrimaryKey", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NoPrimaryKey = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uniques", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uniques = append(m.Uniques, &TableIndex{})
+			if err := m.Uniques[len(m.Uniques)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Indexes = append(m.Indexes, &TableIndex{})
+			if err := m.Indexes[len(m.Indexes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nullable", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nullable = append(m.Nullable, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Defaults", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Defaults == nil {
+				m.Defaults = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDatabus
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDatabus
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthDatabus
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthDatabus
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDatabus
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthDatabus
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthDatabus
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipDatabus(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthDatabus
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Defaults[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Rules == nil {
+				m.Rules = make(map[string]*ColumnRule)
+			}
+			var mapkey string
+			var mapvalue *ColumnRule
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDatabus
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDatabus
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthDatabus
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthDatabus
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDatabus
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthDatabus
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthDatabus
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &ColumnRule{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipDatabus(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthDatabus
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Rules[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field References", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.References = append(m.References, &TableReference{})
+			if err := m.References[len(m.References)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TableReference) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: tableReference: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: tableReference: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = append(m.Fields, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Table", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Table = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableFields", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TableFields = append(m.TableFields, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ColumnRule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: columnRule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: columnRule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Min", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Min = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Max", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Max = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pattern", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pattern = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxLength", wireType)
+			}
+			m.MaxLength = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxLength |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OneOf", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OneOf = append(m.OneOf, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NonEmpty", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NonEmpty = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TableIndex) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: tableIndex: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: tableIndex: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = append(m.Fields, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateConfigReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateConfigReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateConfigReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Head == nil {
+				m.Head = &TableHead{}
+			}
+			if err := m.Head.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Content = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DingtalkID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DingtalkID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowBreaking", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AllowBreaking = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stage", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Stage = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateConfigResp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateConfigResp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateConfigResp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrMsg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrMsg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sinks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sinks = append(m.Sinks, &SinkStatus{})
+			if err := m.Sinks[len(m.Sinks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Violations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Violations = append(m.Violations, &Violation{})
+			if err := m.Violations[len(m.Violations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaChanges", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SchemaChanges = append(m.SchemaChanges, &SchemaChange{})
+			if err := m.SchemaChanges[len(m.SchemaChanges)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rows", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rows = append(m.Rows, &RowChange{})
+			if err := m.Rows[len(m.Rows)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SchemaChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SchemaChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SchemaChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Kind = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldField", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OldField = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OldType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Breaking", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Breaking = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Violation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Violation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Violation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Row", wireType)
+			}
+			m.Row = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Row |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Column", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Column = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SinkStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SinkStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SinkStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrMsg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrMsg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetConfigReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetConfigReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetConfigReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetConfigResp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetConfigResp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetConfigResp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Content = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetSchemaReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetSchemaReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetSchemaReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetSchemaResp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetSchemaResp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetSchemaResp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Head == nil {
+				m.Head = &TableHead{}
+			}
+			if err := m.Head.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RollbackConfigReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RollbackConfigReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RollbackConfigReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DingtalkID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DingtalkID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RollbackConfigResp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RollbackConfigResp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RollbackConfigResp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrMsg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrMsg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sinks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sinks = append(m.Sinks, &SinkStatus{})
+			if err := m.Sinks[len(m.Sinks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DiffConfigReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DiffConfigReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DiffConfigReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromVersion", wireType)
+			}
+			m.FromVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FromVersion |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToVersion", wireType)
+			}
+			m.ToVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ToVersion |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Candidate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Candidate == nil {
+				m.Candidate = &UpdateConfigReq{}
+			}
+			if err := m.Candidate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DiffConfigResp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDatabus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DiffConfigResp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DiffConfigResp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rows", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rows = append(m.Rows, &RowChange{})
+			if err := m.Rows[len(m.Rows)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaChanges", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SchemaChanges = append(m.SchemaChanges, &SchemaChange{})
+			if err := m.SchemaChanges[len(m.SchemaChanges)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDatabus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *SayHelloResp) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Response)
-	if l > 0 {
-		n += 1 + l + sovDatabus(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovDatabus(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozDatabus(x uint64) (n int) {
-	return sovDatabus(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *TableHead) Unmarshal(dAtA []byte) error {
+func (m *RowChange) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1068,15 +7166,15 @@ func (m *TableHead) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: tableHead: wiretype end group for non-group")
+			return fmt.Errorf("proto: RowChange: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: tableHead: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RowChange: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1104,11 +7202,11 @@ func (m *TableHead) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Fields = append(m.Fields, string(dAtA[iNdEx:postIndex]))
+			m.Kind = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Types", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1136,11 +7234,11 @@ func (m *TableHead) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Types = append(m.Types, string(dAtA[iNdEx:postIndex]))
+			m.Key = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Descs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OldRow", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1168,7 +7266,71 @@ func (m *TableHead) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Descs = append(m.Descs, string(dAtA[iNdEx:postIndex]))
+			m.OldRow = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewRow", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewRow = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = append(m.Fields, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1195,7 +7357,7 @@ func (m *TableHead) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateConfigReq) Unmarshal(dAtA []byte) error {
+func (m *PublishConfigReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1218,10 +7380,10 @@ func (m *UpdateConfigReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateConfigReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: PublishConfigReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateConfigReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PublishConfigReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1257,74 +7419,6 @@ func (m *UpdateConfigReq) Unmarshal(dAtA []byte) error {
 			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDatabus
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthDatabus
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthDatabus
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Head == nil {
-				m.Head = &TableHead{}
-			}
-			if err := m.Head.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDatabus
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDatabus
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthDatabus
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Content = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field DingtalkID", wireType)
 			}
@@ -1381,7 +7475,7 @@ func (m *UpdateConfigReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateConfigResp) Unmarshal(dAtA []byte) error {
+func (m *PublishConfigResp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1404,10 +7498,10 @@ func (m *UpdateConfigResp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateConfigResp: wiretype end group for non-group")
+			return fmt.Errorf("proto: PublishConfigResp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateConfigResp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PublishConfigResp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1461,6 +7555,59 @@ func (m *UpdateConfigResp) Unmarshal(dAtA []byte) error {
 			}
 			m.ErrMsg = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sinks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDatabus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sinks = append(m.Sinks, &SinkStatus{})
+			if err := m.Sinks[len(m.Sinks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDatabus(dAtA[iNdEx:])
@@ -1486,7 +7633,7 @@ func (m *UpdateConfigResp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetConfigReq) Unmarshal(dAtA []byte) error {
+func (m *DiscardStagedReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1509,10 +7656,10 @@ func (m *GetConfigReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetConfigReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: DiscardStagedReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetConfigReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DiscardStagedReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1572,7 +7719,7 @@ func (m *GetConfigReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetConfigResp) Unmarshal(dAtA []byte) error {
+func (m *DiscardStagedResp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1595,15 +7742,34 @@ func (m *GetConfigResp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetConfigResp: wiretype end group for non-group")
+			return fmt.Errorf("proto: DiscardStagedResp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetConfigResp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DiscardStagedResp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDatabus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrMsg", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1631,7 +7797,7 @@ func (m *GetConfigResp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Content = string(dAtA[iNdEx:postIndex])
+			m.ErrMsg = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9,6 +9,9 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -20,17 +23,24 @@ const (
 	serverPort = 10000
 	serverAddr = "127.0.0.1:10000"
 
-	testMysqlDsn  = "username:password@tcp(127.0.0.1:3306)/dbname?charset=utf8mb4"
 	testRedisAddr = "127.0.0.1:6379"
 	testRedisPwd  = "password"
 )
 
 func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "e2cdatabus")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
 	go func() {
 		svs := rpcserver.NewService()
-		svs.SetMyqlConnect(testMysqlDsn)
+		err := svs.SetSqliteConnect(filepath.Join(dir, "config.db"))
+		if err != nil {
+			panic(err)
+		}
 		//svs.SetRedisConnect(testRedisAddr, testRedisPwd)
-		err := rpcserver.Start(rpcserver.Conf{
+		err = rpcserver.Start(rpcserver.Conf{
 			Port:      serverPort,
 			AppKey:    testAppKey,
 			AppSecret: testAppSecret,
@@ -114,5 +124,4 @@ func TestUpdateConfig(t *testing.T) {
 	if getResp.Content != updateReq.Content {
 		t.Fatalf("content is diffrent, %v", getResp.Content)
 	}
-	select {}
 }
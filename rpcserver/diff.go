@@ -0,0 +1,167 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"sort"
+	"strconv"
+)
+
+// DiffConfig compares a stored version of a table, or the table currently stored,
+// to another version or a candidate upload
+func (s *Service) DiffConfig(ctx context.Context, req *pb.DiffConfigReq) (resp *pb.DiffConfigResp, err error) {
+	from, err := s.diffTable(ctx, req.Name, req.FromVersion)
+	if err != nil {
+		return
+	}
+	var to *Table
+	switch {
+	case req.Candidate != nil:
+		to = &Table{Name: req.Name, Head: req.Candidate.Head, Content: req.Candidate.Content}
+	case req.ToVersion > 0:
+		to, err = s.diffTable(ctx, req.Name, req.ToVersion)
+	default:
+		err = errors.New("nothing to compare to, set toVersion or candidate")
+	}
+	if err != nil {
+		return
+	}
+	resp = &pb.DiffConfigResp{SchemaChanges: diffHeads(from.Head, to.Head)}
+	resp.Rows, err = diffRows(from, to)
+	return
+}
+
+// diffTable returns a version of the table from the history store, or the table currently
+// stored if version is 0. A table never uploaded is returned with empty content.
+func (s *Service) diffTable(ctx context.Context, name string, version int64) (table *Table, err error) {
+	if version > 0 {
+		if s.history == nil {
+			return nil, errors.New("no history store")
+		}
+		var v *Version
+		v, err = s.history.GetVersion(ctx, name, version)
+		if err != nil {
+			return nil, fmt.Errorf("version %d of %s: %w", version, name, err)
+		}
+		return &Table{Name: name, Head: v.Head, Content: v.Content}, nil
+	}
+	table = &Table{Name: name, Content: "[]"}
+	if len(s.storages) > 0 {
+		var stored *Table
+		stored, err = s.storages[0].storage.ReadTable(ctx, name)
+		if err == ErrTableNotFound {
+			err = nil
+		} else if err == nil {
+			table = stored
+		}
+		if err != nil {
+			return
+		}
+	}
+	table.Head, err = s.storedHead(ctx, name)
+	return
+}
+
+// diffRows matches the rows of the tables by the primary key of the new head,
+// and returns the added and modified rows in the new order, then the removed ones
+func diffRows(from, to *Table) (changes []*pb.RowChange, err error) {
+	oldRows, err := decodeContent(from.Content)
+	if err != nil {
+		return
+	}
+	newRows, err := decodeContent(to.Content)
+	if err != nil {
+		return
+	}
+	var primaryKey []string
+	if to.Head != nil {
+		var keys tableKeys
+		if keys, err = parseTableKeys(to.Head); err != nil {
+			return
+		}
+		primaryKey = keys.PrimaryKey
+	}
+	// rows are matched by identity, and key is the primary key for display
+	identity := func(index int, row map[string]interface{}) string {
+		if len(primaryKey) == 0 {
+			return strconv.Itoa(index)
+		}
+		return keyIdentity(row, primaryKey)
+	}
+	key := func(index int, row map[string]interface{}) string {
+		if len(primaryKey) == 0 {
+			return strconv.Itoa(index)
+		}
+		return rowKey(row, primaryKey)
+	}
+	oldIndexes := make(map[string]int, len(oldRows))
+	for index, row := range oldRows {
+		oldIndexes[identity(index, row)] = index
+	}
+	matched := make(map[int]bool, len(oldRows))
+	for index, row := range newRows {
+		rowKey := key(index, row)
+		oldIndex, ok := oldIndexes[identity(index, row)]
+		if !ok {
+			changes = append(changes, &pb.RowChange{Kind: ChangeAdded, Key: rowKey, NewRow: rowText(row)})
+			continue
+		}
+		matched[oldIndex] = true
+		if fields := diffFields(oldRows[oldIndex], row, to.Head); len(fields) > 0 {
+			changes = append(changes, &pb.RowChange{Kind: ChangeModified, Key: rowKey,
+				OldRow: rowText(oldRows[oldIndex]), NewRow: rowText(row), Fields: fields})
+		}
+	}
+	for index, row := range oldRows {
+		if !matched[index] {
+			changes = append(changes, &pb.RowChange{Kind: ChangeRemoved, Key: key(index, row), OldRow: rowText(row)})
+		}
+	}
+	return
+}
+
+// diffFields returns the fields with different cells, in the order of the head
+// followed by the fields not in the head
+func diffFields(old, row map[string]interface{}, head *pb.TableHead) (fields []string) {
+	order := make(map[string]int)
+	for index, field := range head.GetFields() {
+		order[field] = index
+	}
+	for _, cells := range []map[string]interface{}{old, row} {
+		for field := range cells {
+			if _, ok := order[field]; !ok {
+				order[field] = len(order)
+			}
+		}
+	}
+	for field := range order {
+		if !cellsEqual(old[field], row[field]) {
+			fields = append(fields, field)
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool { return order[fields[i]] < order[fields[j]] })
+	return
+}
+
+// cellsEqual compares cells decoded by decodeContent, numbers are equal if they have
+// the same value, e.g. 1 and 1.0, as storages may format them differently
+func cellsEqual(a, b interface{}) bool {
+	numberA, okA := a.(json.Number)
+	numberB, okB := b.(json.Number)
+	if okA && okB {
+		floatA, errA := numberA.Float64()
+		floatB, errB := numberB.Float64()
+		if errA == nil && errB == nil {
+			return floatA == floatB
+		}
+	}
+	return cellText(a) == cellText(b)
+}
+
+func rowText(row map[string]interface{}) string {
+	bytes, _ := json.Marshal(row)
+	return string(bytes)
+}
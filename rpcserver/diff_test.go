@@ -0,0 +1,91 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"reflect"
+	"testing"
+)
+
+func TestDiffConfig(t *testing.T) {
+	s := NewService()
+	s.SetStorage(NewMemoryStorage())
+	s.SetHistoryStore(NewMemoryHistoryStore())
+	ctx := context.TODO()
+	candidate := &pb.UpdateConfigReq{
+		Name: testTable.Name,
+		Head: &pb.TableHead{
+			Fields: []string{"sid", "type", "name", "event"},
+			Types:  []string{"int", "int", "string", "string"},
+			Descs:  []string{"流水ID", "类型", "名称", "事件"},
+		},
+		Content: `[{"event":"","name":"名称1","sid":1,"type":1},{"event":"","name":"名称3","sid":3,"type":1},` +
+			`{"event":"e","name":"名称2","sid":2,"type":2}]`,
+	}
+	// the table is not uploaded yet, every row is added
+	resp, err := s.DiffConfig(ctx, &pb.DiffConfigReq{Name: testTable.Name, Candidate: testUpdateReq})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Rows) != 2 || resp.Rows[0].Kind != ChangeAdded || resp.Rows[0].Key != "1" || resp.SchemaChanges != nil {
+		t.Errorf("DiffConfig of a new table: %v", resp)
+	}
+
+	if _, err = s.UpdateConfig(ctx, testUpdateReq); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = s.DiffConfig(ctx, &pb.DiffConfigReq{Name: testTable.Name, Candidate: candidate})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &pb.DiffConfigResp{
+		Rows: []*pb.RowChange{
+			{Kind: ChangeModified, Key: "1", OldRow: `{"name":"名称1","sid":1,"type":1}`,
+				NewRow: `{"event":"","name":"名称1","sid":1,"type":1}`, Fields: []string{"event"}},
+			{Kind: ChangeAdded, Key: "3", NewRow: `{"event":"","name":"名称3","sid":3,"type":1}`},
+			{Kind: ChangeModified, Key: "2", OldRow: `{"name":"名称2","sid":2,"type":1}`,
+				NewRow: `{"event":"e","name":"名称2","sid":2,"type":2}`, Fields: []string{"type", "event"}},
+		},
+		SchemaChanges: []*pb.SchemaChange{{Kind: ChangeAdded, Field: "event", NewType: "string"}},
+	}
+	if !reflect.DeepEqual(resp, expected) {
+		t.Errorf("DiffConfig against a candidate: %v", resp)
+	}
+
+	if _, err = s.UpdateConfig(ctx, candidate); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = s.DiffConfig(ctx, &pb.DiffConfigReq{Name: testTable.Name, FromVersion: 2, ToVersion: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Rows) != 3 || resp.Rows[2].Kind != ChangeRemoved || resp.Rows[2].Key != "3" ||
+		len(resp.SchemaChanges) != 1 || resp.SchemaChanges[0].Kind != ChangeRemoved {
+		t.Errorf("DiffConfig between versions: %v", resp)
+	}
+	if _, err = s.DiffConfig(ctx, &pb.DiffConfigReq{Name: testTable.Name, FromVersion: 5, ToVersion: 1}); err == nil {
+		t.Error("DiffConfig from a missing version succeed")
+	}
+	if _, err = s.DiffConfig(ctx, &pb.DiffConfigReq{Name: testTable.Name}); err == nil {
+		t.Error("DiffConfig without anything to compare to succeed")
+	}
+}
+
+func TestCellsEqual(t *testing.T) {
+	cases := []struct {
+		a, b  interface{}
+		equal bool
+	}{
+		{json.Number("1"), json.Number("1.0"), true},
+		{json.Number("1"), json.Number("2"), false},
+		{json.Number("1"), "1", false},
+		{nil, "", false},
+		{map[string]interface{}{"a": json.Number("1")}, map[string]interface{}{"a": json.Number("1")}, true},
+	}
+	for _, c := range cases {
+		if cellsEqual(c.a, c.b) != c.equal {
+			t.Errorf("cellsEqual(%v, %v) should be %v", c.a, c.b, c.equal)
+		}
+	}
+}
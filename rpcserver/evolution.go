@@ -0,0 +1,153 @@
+package rpcserver
+
+import (
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"strings"
+)
+
+// kinds of SchemaChange and RowChange
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeRetyped  = "retyped"
+	ChangeRenamed  = "renamed"
+	ChangeModified = "modified"
+)
+
+// diffHeads classifies the column changes from the stored head to the uploaded one.
+// A removed column and an added column of the same type and the same non-empty desc
+// are taken as a rename, as designers often fix the field name and keep the desc.
+func diffHeads(old, head *pb.TableHead) (changes []*pb.SchemaChange) {
+	if old == nil || head == nil {
+		return
+	}
+	oldTypes := columnTypes(old)
+	newTypes := columnTypes(head)
+	var removed, added []int
+	for index, field := range old.Fields {
+		newType, ok := newTypes[field]
+		if !ok {
+			removed = append(removed, index)
+			continue
+		}
+		if oldType := oldTypes[field]; oldType != newType {
+			changes = append(changes, &pb.SchemaChange{
+				Kind:     ChangeRetyped,
+				Field:    field,
+				OldType:  oldType,
+				NewType:  newType,
+				Breaking: !isWidening(oldType, newType),
+			})
+		}
+	}
+	for index, field := range head.Fields {
+		if _, ok := oldTypes[field]; !ok {
+			added = append(added, index)
+		}
+	}
+	renamed := make(map[int]bool)
+	for _, oldIndex := range removed {
+		oldField := old.Fields[oldIndex]
+		change := &pb.SchemaChange{Kind: ChangeRemoved, Field: oldField, OldType: oldTypes[oldField], Breaking: true}
+		for _, newIndex := range added {
+			field := head.Fields[newIndex]
+			if !renamed[newIndex] && descAt(old, oldIndex) != "" && descAt(old, oldIndex) == descAt(head, newIndex) &&
+				oldTypes[oldField] == newTypes[field] {
+				renamed[newIndex] = true
+				change = &pb.SchemaChange{Kind: ChangeRenamed, Field: field, OldField: oldField,
+					OldType: oldTypes[oldField], NewType: newTypes[field], Breaking: true}
+				break
+			}
+		}
+		changes = append(changes, change)
+	}
+	for _, index := range added {
+		if field := head.Fields[index]; !renamed[index] {
+			changes = append(changes, &pb.SchemaChange{Kind: ChangeAdded, Field: field, NewType: newTypes[field]})
+		}
+	}
+	return
+}
+
+// breakingMessage summarizes the breaking changes, it's empty if there is none
+func breakingMessage(changes []*pb.SchemaChange) string {
+	msgs := make([]string, 0)
+	for _, change := range changes {
+		if !change.Breaking {
+			continue
+		}
+		switch change.Kind {
+		case ChangeRenamed:
+			msgs = append(msgs, fmt.Sprintf("renamed %s to %s", change.OldField, change.Field))
+		case ChangeRetyped:
+			msgs = append(msgs, fmt.Sprintf("retyped %s from %s to %s", change.Field, change.OldType, change.NewType))
+		default:
+			msgs = append(msgs, change.Kind+" "+change.Field)
+		}
+	}
+	if len(msgs) == 0 {
+		return ""
+	}
+	return "breaking schema changes: " + strings.Join(msgs, ", ") + ", upload with allowBreaking to apply them"
+}
+
+// columnTypes returns the normalized types of the head keyed by field,
+// types which can't be parsed are kept as they are
+func columnTypes(head *pb.TableHead) map[string]string {
+	types := make(map[string]string, len(head.Fields))
+	for index, field := range head.Fields {
+		if index >= len(head.Types) {
+			break
+		}
+		types[field] = head.Types[index]
+		if ct, err := ParseColumnType(head.Types[index]); err == nil {
+			types[field] = ct.String()
+		}
+	}
+	return types
+}
+
+// isWidening reports whether every value of the old type is a value of the new type,
+// e.g. int to double, or an enum with more values
+func isWidening(oldType, newType string) bool {
+	oldCT, err := ParseColumnType(oldType)
+	if err != nil {
+		return false
+	}
+	newCT, err := ParseColumnType(newType)
+	if err != nil || oldCT.Array != newCT.Array {
+		return false
+	}
+	switch oldCT.Base {
+	case TypeInt:
+		return newCT.Base == TypeDouble || newCT.Base == TypeFloat
+	case TypeFloat:
+		return newCT.Base == TypeDouble
+	case TypeEnum:
+		if newCT.Base == TypeString {
+			return true
+		}
+		if newCT.Base != TypeEnum {
+			return false
+		}
+		values := make(map[string]bool, len(newCT.EnumValues))
+		for _, value := range newCT.EnumValues {
+			values[value] = true
+		}
+		for _, value := range oldCT.EnumValues {
+			if !values[value] {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func descAt(head *pb.TableHead, index int) string {
+	if index < len(head.Descs) {
+		return head.Descs[index]
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+package rpcserver
+
+import (
+	"context"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"reflect"
+	"testing"
+)
+
+func TestDiffHeads(t *testing.T) {
+	old := &pb.TableHead{
+		Fields: []string{"sid", "type", "name", "rate", "grade", "icon"},
+		Types:  []string{"int", "int", "string", "int", "enum(a,b)", "string"},
+		Descs:  []string{"流水ID", "类型", "名称", "比率", "品质", "图标"},
+	}
+	head := &pb.TableHead{
+		Fields: []string{"sid", "type", "title", "rate", "grade", "event"},
+		Types:  []string{"int", "string", "string", "double", "enum(a, b, c)", "string"},
+		Descs:  []string{"流水ID", "类型", "名称", "比率", "品质", "事件"},
+	}
+	expected := []*pb.SchemaChange{
+		{Kind: ChangeRetyped, Field: "type", OldType: "int", NewType: "string", Breaking: true},
+		{Kind: ChangeRetyped, Field: "rate", OldType: "int", NewType: "double"},
+		{Kind: ChangeRetyped, Field: "grade", OldType: "enum(a,b)", NewType: "enum(a,b,c)"},
+		{Kind: ChangeRenamed, Field: "title", OldField: "name", OldType: "string", NewType: "string", Breaking: true},
+		{Kind: ChangeRemoved, Field: "icon", OldType: "string", Breaking: true},
+		{Kind: ChangeAdded, Field: "event", NewType: "string"},
+	}
+	changes := diffHeads(old, head)
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("changes: %v", changes)
+	}
+	if changes = diffHeads(nil, head); changes != nil {
+		t.Errorf("changes of a new table: %v", changes)
+	}
+}
+
+func TestRejectBreakingChanges(t *testing.T) {
+	s := NewService()
+	s.SetStorage(NewMemoryStorage())
+	ctx := context.TODO()
+	if _, err := s.UpdateConfig(ctx, testUpdateReq); err != nil {
+		t.Fatal(err)
+	}
+	req := *testUpdateReq
+	req.Head = &pb.TableHead{
+		Fields: []string{"sid", "type"},
+		Types:  []string{"int", "int"},
+		Descs:  []string{"流水ID", "类型"},
+	}
+	req.Content = `[{"sid":1,"type":1}]`
+	resp, err := s.UpdateConfig(ctx, &req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != StatusOK || len(resp.SchemaChanges) != 1 || resp.SchemaChanges[0].Kind != ChangeRemoved {
+		t.Errorf("breaking changes are not reported: %v", resp)
+	}
+
+	s.SetRejectBreakingChanges(true)
+	if _, err = s.UpdateConfig(ctx, testUpdateReq); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = s.UpdateConfig(ctx, &req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != StatusBreakingChange ||
+		resp.ErrMsg != "breaking schema changes: removed name, upload with allowBreaking to apply them" {
+		t.Errorf("breaking changes are not rejected: %v", resp)
+	}
+	req.AllowBreaking = true
+	resp, err = s.UpdateConfig(ctx, &req)
+	if err != nil || resp.Status != StatusOK {
+		t.Errorf("breaking changes are not allowed: %v, %v", resp, err)
+	}
+}
@@ -0,0 +1,184 @@
+package rpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"log"
+	"strings"
+)
+
+// WritePolicy decides what UpdateConfig does when some of the storages fail to write
+type WritePolicy int
+
+const (
+	// WriteAllOrNothing restores the storages already written when any storage fails,
+	// and UpdateConfig returns the error
+	WriteAllOrNothing WritePolicy = iota
+	// WriteBestEffort keeps whatever has been written,
+	// and reports the result of every storage in UpdateConfigResp.Sinks
+	WriteBestEffort
+)
+
+const (
+	StatusOK int32 = iota
+	StatusFailed
+	// StatusPartialFailed means some of the storages failed under WriteBestEffort
+	StatusPartialFailed
+	// StatusInvalid means the table is rejected by validation, see UpdateConfigResp.Violations
+	StatusInvalid
+	// StatusBreakingChange means the table is rejected for breaking schema changes,
+	// see UpdateConfigResp.SchemaChanges
+	StatusBreakingChange
+	// StatusVersionFailed means the table is written and notified,
+	// but its version is not recorded in the history store
+	StatusVersionFailed
+	// StatusSchemaFailed means the table is written, recorded and notified,
+	// but its head is not saved in the schema store
+	StatusSchemaFailed
+)
+
+type namedStorage struct {
+	name    string
+	storage Storage
+}
+
+// writeFunc writes the table to a storage, see writeTable and publishTable
+type writeFunc func(ctx context.Context, storage Storage, table *Table) error
+
+func writeTable(ctx context.Context, storage Storage, table *Table) error {
+	return storage.WriteTable(ctx, table)
+}
+
+// snapshot is a storage's table before it is overwritten, table is nil if it didn't exist.
+// err is why the table couldn't be decoded, the table can't be restored then.
+type snapshot struct {
+	name    string
+	storage Storage
+	table   *Table
+	err     error
+}
+
+// dryRunAll checks the write of the table on every storage implementing DryRunner,
+// other storages are skipped
+func (s *Service) dryRunAll(ctx context.Context, table *Table) (sinks []*pb.SinkStatus) {
+	for _, ns := range s.storages {
+		dryRunner, ok := ns.storage.(DryRunner)
+		if !ok {
+			continue
+		}
+		sink := &pb.SinkStatus{Name: ns.name, Status: StatusOK}
+		if err := dryRunner.DryRunTable(ctx, table); err != nil {
+			sink.Status = StatusFailed
+			sink.ErrMsg = err.Error()
+		}
+		sinks = append(sinks, sink)
+	}
+	return
+}
+
+// writeAll writes the table to every storage with write according to the write policy
+func (s *Service) writeAll(ctx context.Context, table *Table, write writeFunc) (sinks []*pb.SinkStatus, err error) {
+	if s.writePolicy == WriteBestEffort {
+		return s.writeBestEffort(ctx, table, write)
+	}
+	// storages may not keep the whole head, e.g. keys and nullable columns of mysql,
+	// so the old tables are restored with the head in the schema store
+	oldHead, err := s.snapshotHead(ctx, table.Name)
+	if err != nil {
+		return
+	}
+	// every storage is snapshotted before the first write, so nothing is written if one can't be read
+	snapshots := make([]snapshot, 0, len(s.storages))
+	for _, ns := range s.storages {
+		sn, snapshotErr := takeSnapshot(ctx, ns, table.Name, oldHead)
+		if snapshotErr != nil {
+			err = fmt.Errorf("%s: %w", ns.name, snapshotErr)
+			return
+		}
+		snapshots = append(snapshots, sn)
+	}
+	written := 0
+	for _, sn := range snapshots {
+		err = write(ctx, sn.storage, table)
+		if err != nil {
+			break
+		}
+		written++
+		sinks = append(sinks, &pb.SinkStatus{Name: sn.name, Status: StatusOK})
+	}
+	if err != nil {
+		errMsgs := make([]string, 0)
+		for i := written - 1; i >= 0; i-- {
+			if restoreErr := snapshots[i].restore(ctx, table.Name); restoreErr != nil {
+				errMsgs = append(errMsgs, snapshots[i].name+": "+restoreErr.Error())
+			}
+		}
+		if len(errMsgs) > 0 {
+			err = fmt.Errorf("%w, and restoring failed: %s", err, strings.Join(errMsgs, "; "))
+		}
+		sinks = nil
+	}
+	return
+}
+
+// snapshotHead returns the head of the stored table in the schema store,
+// it's nil if no schema store is set or the table was never uploaded
+func (s *Service) snapshotHead(ctx context.Context, name string) (head *pb.TableHead, err error) {
+	if s.schemas == nil {
+		return
+	}
+	head, err = s.schemas.LoadSchema(ctx, name)
+	if err == ErrTableNotFound {
+		err = nil
+	}
+	return
+}
+
+func (s *Service) writeBestEffort(ctx context.Context, table *Table, write writeFunc) (sinks []*pb.SinkStatus, err error) {
+	errMsgs := make([]string, 0)
+	for _, ns := range s.storages {
+		sink := &pb.SinkStatus{Name: ns.name, Status: StatusOK}
+		if writeErr := write(ctx, ns.storage, table); writeErr != nil {
+			sink.Status = StatusFailed
+			sink.ErrMsg = writeErr.Error()
+			errMsgs = append(errMsgs, ns.name+": "+writeErr.Error())
+		}
+		sinks = append(sinks, sink)
+	}
+	if len(errMsgs) > 0 && len(errMsgs) == len(s.storages) {
+		err = errors.New(strings.Join(errMsgs, "; "))
+	}
+	return
+}
+
+// takeSnapshot reads the table of a storage before it is overwritten.
+// A table which can't be decoded, e.g. it's written by an older version, is still overwritten
+// as it could never be fixed by uploading it again otherwise, but it can't be restored then.
+func takeSnapshot(ctx context.Context, ns namedStorage, name string, oldHead *pb.TableHead) (sn snapshot, err error) {
+	sn = snapshot{name: ns.name, storage: ns.storage}
+	sn.table, err = ns.storage.ReadTable(ctx, name)
+	if err == ErrTableNotFound {
+		err = nil
+	}
+	if errors.Is(err, ErrUndecodable) {
+		log.Printf("snapshot of %s in %s failed: %v", name, ns.name, err)
+		sn.err, err = err, nil
+	}
+	if sn.table != nil && oldHead != nil {
+		sn.table.Head = oldHead
+	}
+	return
+}
+
+// restore compensates a write, it puts back the old table or deletes the new one
+func (sn snapshot) restore(ctx context.Context, name string) error {
+	if sn.err != nil {
+		return fmt.Errorf("no snapshot: %w", sn.err)
+	}
+	if sn.table == nil {
+		return sn.storage.DeleteTable(ctx, name)
+	}
+	return sn.storage.WriteTable(ctx, sn.table)
+}
@@ -0,0 +1,161 @@
+package rpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"strings"
+	"testing"
+)
+
+// failStorage fails every write
+type failStorage struct {
+	*MemoryStorage
+}
+
+func (f failStorage) WriteTable(ctx context.Context, table *Table) error {
+	return errors.New("write failed")
+}
+
+func TestWritePolicy(t *testing.T) {
+	old := &Table{Name: testTable.Name, Head: testTable.Head, Content: `[]`}
+	first := NewMemoryStorage()
+	first.WriteTable(context.TODO(), old)
+	second := NewMemoryStorage()
+
+	s := NewService()
+	s.AddStorage("first", first)
+	s.AddStorage("second", second)
+	s.AddStorage("fail", failStorage{NewMemoryStorage()})
+	if _, err := s.UpdateConfig(context.TODO(), testUpdateReq); err == nil {
+		t.Fatal("UpdateConfig succeed with a failed storage")
+	}
+	if table, _ := first.ReadTable(context.TODO(), testTable.Name); table.Content != old.Content {
+		t.Errorf("first storage is not restored: %v", table.Content)
+	}
+	if _, err := second.ReadTable(context.TODO(), testTable.Name); err != ErrTableNotFound {
+		t.Errorf("second storage is not restored: %v", err)
+	}
+
+	s.SetWritePolicy(WriteBestEffort)
+	resp, err := s.UpdateConfig(context.TODO(), testUpdateReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != StatusPartialFailed || len(resp.Sinks) != 3 || resp.Sinks[2].Status != StatusFailed {
+		t.Errorf("UpdateConfig resp: %v", resp)
+	}
+	if table, _ := second.ReadTable(context.TODO(), testTable.Name); table.Content != testTable.Content {
+		t.Errorf("second storage is not written: %v", table.Content)
+	}
+}
+
+// headlessStorage keeps only the fields and types of heads like mysql does,
+// and fails writes after the first limit ones
+type headlessStorage struct {
+	*MemoryStorage
+	limit   int
+	written []*Table
+}
+
+func (h *headlessStorage) WriteTable(ctx context.Context, table *Table) error {
+	if len(h.written) >= h.limit {
+		return errors.New("write failed")
+	}
+	h.written = append(h.written, table)
+	return h.MemoryStorage.WriteTable(ctx, table)
+}
+
+func (h *headlessStorage) ReadTable(ctx context.Context, name string) (*Table, error) {
+	table, err := h.MemoryStorage.ReadTable(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &Table{Name: table.Name, Head: &pb.TableHead{Fields: table.Head.Fields, Types: table.Head.Types}, Content: table.Content}, nil
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	ctx := context.TODO()
+	head := &pb.TableHead{Fields: testTable.Head.Fields, Types: testTable.Head.Types, Descs: testTable.Head.Descs, PrimaryKey: []string{"sid", "type"}}
+	old := &Table{Name: testTable.Name, Head: head, Content: `[]`}
+	first := &headlessStorage{MemoryStorage: NewMemoryStorage(), limit: 3}
+	first.MemoryStorage.WriteTable(ctx, old)
+	schemas := NewMemorySchemaStore()
+	schemas.SaveSchema(ctx, old.Name, head)
+
+	s := NewService()
+	s.SetSchemaStore(schemas)
+	s.AddStorage("first", first)
+	s.AddStorage("fail", failStorage{NewMemoryStorage()})
+	if _, err := s.UpdateConfig(ctx, testUpdateReq); err == nil {
+		t.Fatal("UpdateConfig succeed with a failed storage")
+	}
+	if len(first.written) != 2 || first.written[1].Content != old.Content || first.written[1].Head != head {
+		t.Errorf("first storage is not restored with the stored head: %v", first.written)
+	}
+
+	// the restore fails too, as the first storage fails after its 3rd write:
+	// the upload and the restore above, and the upload here
+	_, err := s.UpdateConfig(ctx, testUpdateReq)
+	if err == nil || !strings.Contains(err.Error(), "restoring failed: first: write failed") {
+		t.Errorf("restore error is not reported: %v", err)
+	}
+}
+
+// undecodableStorage fails to decode its tables, like mysql decoding cells by a stale head
+type undecodableStorage struct {
+	*MemoryStorage
+}
+
+func (u undecodableStorage) ReadTable(ctx context.Context, name string) (*Table, error) {
+	return nil, fmt.Errorf("%w: field sid: bad cell", ErrUndecodable)
+}
+
+func TestUndecodableSnapshot(t *testing.T) {
+	ctx := context.TODO()
+	first := undecodableStorage{NewMemoryStorage()}
+	schemas := NewMemorySchemaStore()
+	schemas.SaveSchema(ctx, testTable.Name, testTable.Head)
+	s := NewService()
+	s.SetSchemaStore(schemas)
+	s.AddStorage("first", first)
+	if _, err := s.UpdateConfig(ctx, testUpdateReq); err != nil {
+		t.Fatal(err)
+	}
+	if table, _ := first.MemoryStorage.ReadTable(ctx, testTable.Name); table == nil || table.Content != testTable.Content {
+		t.Errorf("table is not written: %v", table)
+	}
+
+	// the table can't be restored, which is reported
+	s.AddStorage("fail", failStorage{NewMemoryStorage()})
+	_, err := s.UpdateConfig(ctx, testUpdateReq)
+	if err == nil || !strings.Contains(err.Error(), "restoring failed: first: no snapshot: table can't be decoded") {
+		t.Errorf("restore error is not reported: %v", err)
+	}
+}
+
+// unreadableStorage can't read its tables at all, e.g. the connection is lost
+type unreadableStorage struct {
+	*MemoryStorage
+}
+
+func (u unreadableStorage) ReadTable(ctx context.Context, name string) (*Table, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestUnreadableSnapshot(t *testing.T) {
+	ctx := context.TODO()
+	first := NewMemoryStorage()
+	s := NewService()
+	s.AddStorage("first", first)
+	s.AddStorage("unreadable", unreadableStorage{NewMemoryStorage()})
+	_, err := s.UpdateConfig(ctx, testUpdateReq)
+	if err == nil || !strings.Contains(err.Error(), "unreadable: connection refused") {
+		t.Errorf("snapshot error is not reported: %v", err)
+	}
+	// nothing is written, so nothing needs to be restored
+	if _, err := first.ReadTable(ctx, testTable.Name); err != ErrTableNotFound {
+		t.Errorf("table is written before the failed snapshot, err: %v", err)
+	}
+}
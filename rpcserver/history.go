@@ -0,0 +1,375 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"github.com/go-redis/redis"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+const (
+	// defaultHistoryRetention is how many versions of a table are kept by default
+	defaultHistoryRetention = 30
+	// sqlHistoryTable keeps the versions of every table in sql storages
+	sqlHistoryTable = metaTablePrefix + "history"
+	// redisHistoryKey is a hash of version number to the json version of a table,
+	// redisVersionKey is the counter of version numbers
+	redisHistoryKey = "e2cdatabus:history:"
+	redisVersionKey = "e2cdatabus:version:"
+	// sqlHistoryRetries is how many times a version is added when concurrent uploads conflict
+	sqlHistoryRetries = 3
+	// mysqlDuplicateEntry and pgUniqueViolation are the error codes of key conflicts
+	mysqlDuplicateEntry = 1062
+	pgUniqueViolation   = "23505"
+	// mysqlDeadlock, pgSerializationFailure and pgDeadlockDetected are the error codes
+	// of transactions aborted by concurrent ones
+	mysqlDeadlock          = 1213
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+// ErrVersionNotFound is returned by HistoryStore for versions which don't exist or are not retained
+var ErrVersionNotFound = errors.New("version not found")
+
+// errVersionNotRecorded is wrapped in the error of Service.apply
+// when the table is written but its version is not recorded
+var errVersionNotRecorded = errors.New("version is not recorded")
+
+// Version is an immutable upload of a table
+type Version struct {
+	Table string `json:"table"`
+	// Number increases by one with each upload of the table, starting from 1
+	Number      int64  `json:"number"`
+	ContentHash string `json:"contentHash"`
+	DingtalkID  string `json:"dingtalkID"`
+	// ApprovedBy is the dingtalkID of who published the staged upload, it's empty for direct uploads
+	ApprovedBy string        `json:"approvedBy,omitempty"`
+	Timestamp  int64         `json:"timestamp"`
+	Head       *pb.TableHead `json:"head"`
+	Content    string        `json:"content"`
+}
+
+// HistoryStore keeps the versions of tables
+type HistoryStore interface {
+	// AddVersion records the version with the next number of the table and sets v.Number,
+	// older versions are removed to keep at most retention versions, zero means no limit.
+	// v.Number is left unchanged if the version is not recorded.
+	AddVersion(ctx context.Context, v *Version, retention int) error
+	// ListVersions returns the retained versions of the table from the newest, without content
+	ListVersions(ctx context.Context, table string) ([]*Version, error)
+	// GetVersion returns ErrVersionNotFound if the version is not retained
+	GetVersion(ctx context.Context, table string, number int64) (*Version, error)
+}
+
+// newVersion builds the version of a table uploaded by dingtalkID
+func newVersion(table *Table, dingtalkID string, timestamp int64) *Version {
+	return &Version{
+		Table:       table.Name,
+		ContentHash: contentHash(table.Content),
+		DingtalkID:  dingtalkID,
+		Timestamp:   timestamp,
+		Head:        table.Head,
+		Content:     table.Content,
+	}
+}
+
+// MemoryHistoryStore keeps the retained versions of each table in memory from the oldest,
+// the numbers keep increasing after old versions are removed
+type MemoryHistoryStore struct {
+	mu       sync.RWMutex
+	versions map[string][]*Version
+	numbers  map[string]int64
+}
+
+// NewMemoryHistoryStore return a HistoryStore backed by memory
+func NewMemoryHistoryStore() *MemoryHistoryStore {
+	return &MemoryHistoryStore{versions: make(map[string][]*Version), numbers: make(map[string]int64)}
+}
+
+func (m *MemoryHistoryStore) AddVersion(ctx context.Context, v *Version, retention int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.numbers[v.Table]++
+	v.Number = m.numbers[v.Table]
+	stored := *v
+	versions := append(m.versions[v.Table], &stored)
+	if retention > 0 && len(versions) > retention {
+		versions = versions[len(versions)-retention:]
+	}
+	m.versions[v.Table] = versions
+	return nil
+}
+
+func (m *MemoryHistoryStore) ListVersions(ctx context.Context, table string) ([]*Version, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	versions := make([]*Version, 0, len(m.versions[table]))
+	for i := len(m.versions[table]) - 1; i >= 0; i-- {
+		v := *m.versions[table][i]
+		v.Content = ""
+		versions = append(versions, &v)
+	}
+	return versions, nil
+}
+
+func (m *MemoryHistoryStore) GetVersion(ctx context.Context, table string, number int64) (*Version, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, v := range m.versions[table] {
+		if v.Number == number {
+			res := *v
+			return &res, nil
+		}
+	}
+	return nil, ErrVersionNotFound
+}
+
+// SqlHistoryStore keeps versions with their whole content in the table e2cdatabus_history,
+// keyed by the table name and the version number
+type SqlHistoryStore struct {
+	db *sqlx.DB
+	// table is the history table qualified with its database
+	table string
+}
+
+// sqlVersion is a row of e2cdatabus_history
+type sqlVersion struct {
+	Table       string `db:"table_name"`
+	Number      int64  `db:"version"`
+	ContentHash string `db:"content_hash"`
+	DingtalkID  string `db:"dingtalk_id"`
+	ApprovedBy  string `db:"approved_by"`
+	Timestamp   int64  `db:"created_at"`
+	Head        string `db:"head"`
+	Content     string `db:"content"`
+}
+
+// NewSqlHistoryStore return a HistoryStore backed by db, the history table is created in database
+// if not exists, the default database of the connection is used if database is empty
+func NewSqlHistoryStore(db *sqlx.DB, database string) (*SqlHistoryStore, error) {
+	table, err := metaTableName(db, database, sqlHistoryTable)
+	if err != nil {
+		return nil, err
+	}
+	// text of mysql is limited to 64KB, which is not enough for the content
+	textType := "TEXT"
+	if db.DriverName() == "mysql" {
+		textType = "LONGTEXT"
+	}
+	_, err = db.Exec("CREATE TABLE IF NOT EXISTS " + table + " (" +
+		"table_name VARCHAR(64) NOT NULL, version BIGINT NOT NULL, content_hash VARCHAR(64) NOT NULL, " +
+		"dingtalk_id VARCHAR(64) NOT NULL, approved_by VARCHAR(64) NOT NULL, created_at BIGINT NOT NULL, head " + textType + " NOT NULL, " +
+		"content " + textType + " NOT NULL, PRIMARY KEY (table_name, version))")
+	if err != nil {
+		return nil, err
+	}
+	return &SqlHistoryStore{db: db, table: table}, nil
+}
+
+// AddVersion takes the next number in a transaction, the primary key makes concurrent
+// uploads of the same table conflict instead of sharing a number, and the loser retries.
+// Transactions aborted by deadlocks, serialization failures or a busy sqlite are retried too.
+func (q *SqlHistoryStore) AddVersion(ctx context.Context, v *Version, retention int) (err error) {
+	for i := 0; i < sqlHistoryRetries; i++ {
+		err = q.addVersion(ctx, v, retention)
+		if !isConflict(err) {
+			break
+		}
+	}
+	return
+}
+
+func (q *SqlHistoryStore) addVersion(ctx context.Context, v *Version, retention int) (err error) {
+	head, err := json.Marshal(v.Head)
+	if err != nil {
+		return
+	}
+	tx, err := q.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	var last int64
+	err = tx.GetContext(ctx, &last, q.db.Rebind("SELECT COALESCE(MAX(version), 0) FROM "+q.table+" WHERE table_name = ?"), v.Table)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, q.db.Rebind("INSERT INTO "+q.table+
+			" (table_name, version, content_hash, dingtalk_id, approved_by, created_at, head, content) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"),
+			v.Table, last+1, v.ContentHash, v.DingtalkID, v.ApprovedBy, v.Timestamp, string(head), v.Content)
+	}
+	if err == nil && retention > 0 {
+		_, err = tx.ExecContext(ctx, q.db.Rebind("DELETE FROM "+q.table+" WHERE table_name = ? AND version <= ?"),
+			v.Table, last+1-int64(retention))
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+	v.Number = last + 1
+	return
+}
+
+// isConflict reports whether err is caused by a concurrent transaction of mysql, postgres or sqlite,
+// i.e. a primary key or unique conflict, a deadlock, a serialization failure or a busy database
+func isConflict(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	var pqErr *pq.Error
+	var sqliteErr sqlite3.Error
+	switch {
+	case errors.As(err, &mysqlErr):
+		return mysqlErr.Number == mysqlDuplicateEntry || mysqlErr.Number == mysqlDeadlock
+	case errors.As(err, &pqErr):
+		return pqErr.Code == pgUniqueViolation || pqErr.Code == pgSerializationFailure || pqErr.Code == pgDeadlockDetected
+	case errors.As(err, &sqliteErr):
+		return sqliteErr.Code == sqlite3.ErrBusy ||
+			sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey || sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+	}
+	return false
+}
+
+func (q *SqlHistoryStore) ListVersions(ctx context.Context, table string) (versions []*Version, err error) {
+	var rows []sqlVersion
+	err = q.db.SelectContext(ctx, &rows, q.db.Rebind("SELECT table_name, version, content_hash, dingtalk_id, approved_by, created_at, head FROM "+
+		q.table+" WHERE table_name = ? ORDER BY version DESC"), table)
+	if err != nil {
+		return
+	}
+	versions = make([]*Version, 0, len(rows))
+	for _, row := range rows {
+		var v *Version
+		if v, err = row.version(); err != nil {
+			return
+		}
+		versions = append(versions, v)
+	}
+	return
+}
+
+func (q *SqlHistoryStore) GetVersion(ctx context.Context, table string, number int64) (v *Version, err error) {
+	var rows []sqlVersion
+	err = q.db.SelectContext(ctx, &rows, q.db.Rebind("SELECT table_name, version, content_hash, dingtalk_id, approved_by, created_at, head, content FROM "+
+		q.table+" WHERE table_name = ? AND version = ?"), table, number)
+	if err != nil {
+		return
+	}
+	if len(rows) == 0 {
+		return nil, ErrVersionNotFound
+	}
+	return rows[0].version()
+}
+
+func (r sqlVersion) version() (*Version, error) {
+	v := &Version{
+		Table:       r.Table,
+		Number:      r.Number,
+		ContentHash: r.ContentHash,
+		DingtalkID:  r.DingtalkID,
+		ApprovedBy:  r.ApprovedBy,
+		Timestamp:   r.Timestamp,
+		Head:        &pb.TableHead{},
+		Content:     r.Content,
+	}
+	return v, json.Unmarshal([]byte(r.Head), v.Head)
+}
+
+// RedisHistoryStore keeps the versions of a table as json in the hash e2cdatabus:history:<table>
+type RedisHistoryStore struct {
+	redis  redis.UniversalClient
+	prefix string
+}
+
+// NewRedisHistoryStore return a HistoryStore backed by redis, prefix is prepended to the keys
+func NewRedisHistoryStore(client redis.UniversalClient, prefix string) *RedisHistoryStore {
+	return &RedisHistoryStore{redis: client, prefix: prefix}
+}
+
+// historyKey and versionKey share the hash tag of the table, so they stay in one cluster slot
+func (r *RedisHistoryStore) historyKey(table string) string {
+	return r.prefix + redisHistoryKey + "{" + table + "}"
+}
+
+func (r *RedisHistoryStore) versionKey(table string) string {
+	return r.prefix + redisVersionKey + "{" + table + "}"
+}
+
+func (r *RedisHistoryStore) AddVersion(ctx context.Context, v *Version, retention int) (err error) {
+	number, err := r.redis.Incr(r.versionKey(v.Table)).Result()
+	if err != nil {
+		return
+	}
+	stored := *v
+	stored.Number = number
+	bytes, err := json.Marshal(&stored)
+	if err != nil {
+		return
+	}
+	// the number is set only when the version is stored, a number taken by a failed upload is skipped
+	err = r.redis.HSet(r.historyKey(v.Table), strconv.FormatInt(number, 10), string(bytes)).Err()
+	if err != nil {
+		return
+	}
+	v.Number = number
+	if retention > 0 {
+		r.removeExpired(v.Table, number-int64(retention))
+	}
+	return
+}
+
+// removeExpired removes the versions up to number, failures are only logged
+// as the new version is recorded already
+func (r *RedisHistoryStore) removeExpired(table string, number int64) {
+	fields, err := r.redis.HKeys(r.historyKey(table)).Result()
+	expired := make([]string, 0)
+	for _, field := range fields {
+		if n, _ := strconv.ParseInt(field, 10, 64); n <= number {
+			expired = append(expired, field)
+		}
+	}
+	if err == nil && len(expired) > 0 {
+		err = r.redis.HDel(r.historyKey(table), expired...).Err()
+	}
+	if err != nil {
+		log.Printf("remove expired versions of %s failed: %v", table, err)
+	}
+}
+
+func (r *RedisHistoryStore) ListVersions(ctx context.Context, table string) (versions []*Version, err error) {
+	values, err := r.redis.HGetAll(r.historyKey(table)).Result()
+	if err != nil {
+		return
+	}
+	versions = make([]*Version, 0, len(values))
+	for _, value := range values {
+		v := &Version{}
+		if err = json.Unmarshal([]byte(value), v); err != nil {
+			return
+		}
+		v.Content = ""
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Number > versions[j].Number })
+	return
+}
+
+func (r *RedisHistoryStore) GetVersion(ctx context.Context, table string, number int64) (v *Version, err error) {
+	value, err := r.redis.HGet(r.historyKey(table), strconv.FormatInt(number, 10)).Result()
+	if err == redis.Nil {
+		err = ErrVersionNotFound
+	}
+	if err != nil {
+		return
+	}
+	v = &Version{}
+	err = json.Unmarshal([]byte(value), v)
+	return
+}
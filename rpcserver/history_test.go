@@ -0,0 +1,211 @@
+package rpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/alicebob/miniredis/v2"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"github.com/go-redis/redis"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testHistoryStores returns a HistoryStore of each kind, the redis one on miniredis
+func testHistoryStores(t *testing.T) map[string]HistoryStore {
+	dir, err := ioutil.TempDir("", "e2cdatabus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	s := NewService()
+	if err = s.SetSqliteConnect(filepath.Join(dir, "config.db")); err != nil {
+		t.Fatal(err)
+	}
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(mr.Close)
+	return map[string]HistoryStore{
+		"memory": NewMemoryHistoryStore(),
+		"sql":    s.history,
+		"redis":  NewRedisHistoryStore(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "config:"),
+	}
+}
+
+func TestHistoryStore(t *testing.T) {
+	ctx := context.TODO()
+	for name, store := range testHistoryStores(t) {
+		t.Run(name, func(t *testing.T) {
+			if _, err := store.GetVersion(ctx, testTable.Name, 1); err != ErrVersionNotFound {
+				t.Fatalf("get missing version, err: %v", err)
+			}
+			for i := 1; i <= 4; i++ {
+				v := newVersion(testTable, "fandy", int64(i))
+				if err := store.AddVersion(ctx, v, 3); err != nil {
+					t.Fatal(err)
+				}
+				if v.Number != int64(i) {
+					t.Errorf("version number %d, expected %d", v.Number, i)
+				}
+			}
+			versions, err := store.ListVersions(ctx, testTable.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(versions) != 3 || versions[0].Number != 4 || versions[2].Number != 2 || versions[0].Content != "" {
+				t.Errorf("versions: %v", versions)
+			}
+			if _, err = store.GetVersion(ctx, testTable.Name, 1); err != ErrVersionNotFound {
+				t.Errorf("version 1 is retained, err: %v", err)
+			}
+			v, err := store.GetVersion(ctx, testTable.Name, 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := newVersion(testTable, "fandy", 3)
+			expected.Number = 3
+			if !reflect.DeepEqual(v, expected) {
+				t.Errorf("version 3: %+v", v)
+			}
+		})
+	}
+}
+
+func TestUpdateConfigVersions(t *testing.T) {
+	s := NewService()
+	s.SetStorage(NewMemoryStorage())
+	s.SetHistoryStore(NewMemoryHistoryStore())
+	s.SetTableHistoryRetention(testTable.Name, 2)
+	ctx := context.TODO()
+	for i := 1; i <= 3; i++ {
+		resp, err := s.UpdateConfig(ctx, testUpdateReq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Version != int64(i) {
+			t.Errorf("version %d, expected %d", resp.Version, i)
+		}
+	}
+	versions, err := s.history.ListVersions(ctx, testTable.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 || versions[0].DingtalkID != testUpdateReq.DingtalkID || versions[0].ContentHash != contentHash(testTable.Content) {
+		t.Errorf("versions: %v", versions)
+	}
+}
+
+// recordNotifier keeps the notifications it's sent
+type recordNotifier struct {
+	notifications []*Notification
+}
+
+func (r *recordNotifier) Notify(ctx context.Context, n *Notification) error {
+	r.notifications = append(r.notifications, n)
+	return nil
+}
+
+func TestRollbackConfig(t *testing.T) {
+	storage := NewMemoryStorage()
+	notifier := &recordNotifier{}
+	s := NewService()
+	s.SetStorage(storage)
+	s.SetNotifier(notifier)
+	ctx := context.TODO()
+	req := &pb.RollbackConfigReq{Name: testTable.Name, Version: 1, DingtalkID: "admin"}
+	if _, err := s.RollbackConfig(ctx, req); err == nil {
+		t.Error("RollbackConfig without history store succeed")
+	}
+
+	s.SetHistoryStore(NewMemoryHistoryStore())
+	if _, err := s.UpdateConfig(ctx, testUpdateReq); err != nil {
+		t.Fatal(err)
+	}
+	bad := *testUpdateReq
+	bad.Content = `[]`
+	if _, err := s.UpdateConfig(ctx, &bad); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := s.RollbackConfig(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != StatusOK || resp.Version != 3 {
+		t.Errorf("RollbackConfig resp: %v", resp)
+	}
+	if table, _ := storage.ReadTable(ctx, testTable.Name); table.Content != testTable.Content {
+		t.Errorf("content is not restored: %v", table.Content)
+	}
+	if n := notifier.notifications[len(notifier.notifications)-1]; n.Version != 3 || n.DingtalkID != "admin" || n.RowCount != 2 {
+		t.Errorf("notification: %+v", n)
+	}
+
+	req.Version = 10
+	resp, err = s.RollbackConfig(ctx, req)
+	if err != nil || resp.Status != StatusFailed || resp.ErrMsg != "version 10 of item_list not found" {
+		t.Errorf("RollbackConfig to a missing version: %v, %v", resp, err)
+	}
+}
+
+// failHistoryStore fails to add versions
+type failHistoryStore struct {
+	*MemoryHistoryStore
+}
+
+func (f failHistoryStore) AddVersion(ctx context.Context, v *Version, retention int) error {
+	return errors.New("add failed")
+}
+
+func TestVersionFailed(t *testing.T) {
+	storage := NewMemoryStorage()
+	notifier := &recordNotifier{}
+	s := NewService()
+	s.SetStorage(storage)
+	s.SetNotifier(notifier)
+	s.SetHistoryStore(failHistoryStore{NewMemoryHistoryStore()})
+	ctx := context.TODO()
+	resp, err := s.UpdateConfig(ctx, testUpdateReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != StatusVersionFailed || resp.Version != 0 || !strings.Contains(resp.ErrMsg, "add failed") {
+		t.Errorf("UpdateConfig resp: %v", resp)
+	}
+	if table, _ := storage.ReadTable(ctx, testTable.Name); table == nil || table.Content != testTable.Content {
+		t.Errorf("table is not written: %v", table)
+	}
+	if len(notifier.notifications) != 1 || notifier.notifications[0].Version != 0 {
+		t.Errorf("notifications: %v", notifier.notifications)
+	}
+}
+
+func TestIsConflict(t *testing.T) {
+	store := testHistoryStores(t)["sql"].(*SqlHistoryStore)
+	insert := "INSERT INTO " + sqlHistoryTable + " (table_name, version, content_hash, dingtalk_id, approved_by, created_at, head, content) " +
+		"VALUES ('item_list', 1, '', '', '', 0, '', '')"
+	if _, err := store.db.Exec(insert); err != nil {
+		t.Fatal(err)
+	}
+	_, err := store.db.Exec(insert)
+	if !isConflict(err) {
+		t.Errorf("sqlite conflict: %v", err)
+	}
+	for _, err := range []error{&mysql.MySQLError{Number: 1062}, &mysql.MySQLError{Number: 1213}, &pq.Error{Code: "23505"},
+		&pq.Error{Code: "40001"}, &pq.Error{Code: "40P01"}, sqlite3.Error{Code: sqlite3.ErrBusy}} {
+		if !isConflict(fmt.Errorf("insert: %w", err)) {
+			t.Errorf("conflict: %v", err)
+		}
+	}
+	if isConflict(nil) || isConflict(&mysql.MySQLError{Number: 1146}) || isConflict(errors.New("failed")) {
+		t.Error("other errors are conflicts")
+	}
+}
@@ -0,0 +1,143 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"strings"
+)
+
+const (
+	uniquePrefix = "uk_"
+	indexPrefix  = "idx_"
+)
+
+// tableKeys are the primary key, unique constraints and secondary indexes declared by a table head
+type tableKeys struct {
+	// PrimaryKey is empty for tables with noPrimaryKey
+	PrimaryKey []string
+	Uniques    []*pb.TableIndex
+	Indexes    []*pb.TableIndex
+}
+
+// parseTableKeys checks the keys of the head refer to its fields, and names the unnamed
+// indexes after their fields, e.g. "idx_level_type". The primary key is the first field
+// unless the head declares another one.
+func parseTableKeys(head *pb.TableHead) (keys tableKeys, err error) {
+	fields := make(map[string]bool, len(head.Fields))
+	for _, field := range head.Fields {
+		fields[field] = true
+	}
+	switch {
+	case head.NoPrimaryKey && len(head.PrimaryKey) > 0:
+		err = errors.New("primary key is declared with noPrimaryKey")
+		return
+	case len(head.PrimaryKey) > 0:
+		keys.PrimaryKey = head.PrimaryKey
+	case !head.NoPrimaryKey && len(head.Fields) > 0:
+		keys.PrimaryKey = head.Fields[:1]
+	}
+	if err = checkKeyFields("primary key", keys.PrimaryKey, fields); err != nil {
+		return
+	}
+	for _, field := range head.Nullable {
+		for _, key := range keys.PrimaryKey {
+			if field == key {
+				err = fmt.Errorf("primary key field %q can't be nullable", field)
+				return
+			}
+		}
+	}
+	names := make(map[string]bool)
+	keys.Uniques, err = nameIndexes(head.Uniques, uniquePrefix, fields, names)
+	if err == nil {
+		keys.Indexes, err = nameIndexes(head.Indexes, indexPrefix, fields, names)
+	}
+	return
+}
+
+// keyFields returns the fields used by any key, mysql can't index text columns without a length
+func (k tableKeys) keyFields() map[string]bool {
+	res := make(map[string]bool)
+	for _, field := range k.PrimaryKey {
+		res[field] = true
+	}
+	for _, index := range append(append([]*pb.TableIndex{}, k.Uniques...), k.Indexes...) {
+		for _, field := range index.Fields {
+			res[field] = true
+		}
+	}
+	return res
+}
+
+// nameIndexes checks the indexes and returns copies of them with names, names collects
+// the names of all indexes of the table to find duplicates
+func nameIndexes(indexes []*pb.TableIndex, prefix string, fields, names map[string]bool) (res []*pb.TableIndex, err error) {
+	for _, index := range indexes {
+		name := index.Name
+		if name == "" {
+			name = prefix + strings.Join(index.Fields, "_")
+		}
+		if err = checkIdentifier(name); err != nil {
+			return
+		}
+		if names[name] {
+			return nil, fmt.Errorf("duplicate index name %q", name)
+		}
+		names[name] = true
+		if len(index.Fields) == 0 {
+			return nil, fmt.Errorf("index %s has no fields", name)
+		}
+		if err = checkKeyFields("index "+name, index.Fields, fields); err != nil {
+			return
+		}
+		res = append(res, &pb.TableIndex{Name: name, Fields: index.Fields})
+	}
+	return
+}
+
+// checkKeyFields makes sure the fields of a key are distinct fields of the head
+func checkKeyFields(key string, keyFields []string, fields map[string]bool) error {
+	seen := make(map[string]bool, len(keyFields))
+	for _, field := range keyFields {
+		if !fields[field] {
+			return fmt.Errorf("%s: unknown field %q", key, field)
+		}
+		if seen[field] {
+			return fmt.Errorf("%s: duplicate field %q", key, field)
+		}
+		seen[field] = true
+	}
+	return nil
+}
+
+// rowKey formats the cells of the key fields of a row for display and as the fields of the
+// redis hash layout, values are joined by ":" so it's ambiguous if they contain ":",
+// use keyIdentity to compare keys
+func rowKey(row map[string]interface{}, keyFields []string) string {
+	return strings.Join(keyValues(row, keyFields), ":")
+}
+
+// keyIdentity encodes the cells of the key fields of a row as a json array,
+// rows have the same identity only if their key cells are the same
+func keyIdentity(row map[string]interface{}, keyFields []string) string {
+	bytes, _ := json.Marshal(keyValues(row, keyFields))
+	return string(bytes)
+}
+
+func keyValues(row map[string]interface{}, keyFields []string) []string {
+	values := make([]string, 0, len(keyFields))
+	for _, field := range keyFields {
+		switch val := row[field].(type) {
+		case string:
+			values = append(values, val)
+		case json.Number:
+			values = append(values, val.String())
+		default:
+			bytes, _ := json.Marshal(val)
+			values = append(values, string(bytes))
+		}
+	}
+	return values
+}
@@ -0,0 +1,153 @@
+package rpcserver
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"github.com/go-redis/redis"
+	"github.com/jmoiron/sqlx"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testKeyedHead = &pb.TableHead{
+	Fields:     []string{"level", "type", "name", "reward"},
+	Types:      []string{"int", "int", "string", "int"},
+	Descs:      []string{"等级", "类型", "名称", "奖励"},
+	PrimaryKey: []string{"level", "type"},
+	Uniques:    []*pb.TableIndex{{Fields: []string{"name"}}},
+	Indexes:    []*pb.TableIndex{{Name: "idx_reward", Fields: []string{"reward"}}},
+}
+
+func TestParseTableKeys(t *testing.T) {
+	keys, err := parseTableKeys(testKeyedHead)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(keys.PrimaryKey, []string{"level", "type"}) || keys.Uniques[0].Name != "uk_name" {
+		t.Errorf("keys: %v", keys)
+	}
+	keys, err = parseTableKeys(testTable.Head)
+	if err != nil || !reflect.DeepEqual(keys.PrimaryKey, []string{"sid"}) {
+		t.Errorf("default primary key: %v, %v", keys.PrimaryKey, err)
+	}
+	keys, err = parseTableKeys(&pb.TableHead{Fields: []string{"sid"}, NoPrimaryKey: true})
+	if err != nil || len(keys.PrimaryKey) != 0 {
+		t.Errorf("no primary key: %v, %v", keys.PrimaryKey, err)
+	}
+	for _, head := range []*pb.TableHead{
+		{Fields: []string{"sid"}, PrimaryKey: []string{"id"}},
+		{Fields: []string{"sid"}, PrimaryKey: []string{"sid", "sid"}},
+		{Fields: []string{"sid"}, PrimaryKey: []string{"sid"}, NoPrimaryKey: true},
+		{Fields: []string{"sid"}, Indexes: []*pb.TableIndex{{Name: "idx"}}},
+		{Fields: []string{"sid"}, Indexes: []*pb.TableIndex{{Name: "idx", Fields: []string{"sid"}}, {Name: "idx", Fields: []string{"sid"}}}},
+		{Fields: []string{"sid"}, Indexes: []*pb.TableIndex{{Name: "idx;drop", Fields: []string{"sid"}}}},
+	} {
+		if _, err = parseTableKeys(head); err == nil {
+			t.Errorf("invalid keys are accepted: %v", head)
+		}
+	}
+}
+
+func TestSqliteKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "e2cdatabus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := sqlx.Connect("sqlite3", filepath.Join(dir, "config.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	storage := NewSqliteStorage(db)
+	table := &Table{
+		Name:    "level_reward",
+		Head:    testKeyedHead,
+		Content: `[{"level":1,"name":"a","reward":10,"type":1},{"level":1,"name":"b","reward":10,"type":2}]`,
+	}
+	// write twice to make sure the indexes of the old table don't collide
+	for i := 0; i < 2; i++ {
+		if err = storage.WriteTable(context.TODO(), table); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var indexes []string
+	err = db.Select(&indexes, "SELECT name FROM sqlite_master WHERE type = 'index' AND sql IS NOT NULL ORDER BY name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(indexes, []string{"level_reward_idx_reward", "level_reward_uk_name"}) {
+		t.Errorf("indexes: %v", indexes)
+	}
+	for _, content := range []string{
+		`[{"level":1,"name":"a","reward":10,"type":1},{"level":1,"name":"b","reward":10,"type":1}]`,
+		`[{"level":1,"name":"a","reward":10,"type":1},{"level":1,"name":"a","reward":10,"type":2}]`,
+	} {
+		dup := *table
+		dup.Content = content
+		if err = storage.WriteTable(context.TODO(), &dup); err == nil {
+			t.Errorf("duplicate key is written: %v", content)
+		}
+	}
+}
+
+func TestRedisHashKeys(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	storage := NewRedisStorage(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	storage.SetLayout(RedisLayoutHash)
+	table := &Table{
+		Name:    "level_reward",
+		Head:    testKeyedHead,
+		Content: `[{"level":1,"name":"a","reward":10,"type":1},{"level":1,"name":"b","reward":10,"type":2}]`,
+	}
+	if err = storage.WriteTable(context.TODO(), table); err != nil {
+		t.Fatal(err)
+	}
+	if row := mr.HGet(table.Name, "1:2"); row != `{"level":1,"name":"b","reward":10,"type":2}` {
+		t.Errorf("row of primary key 1:2: %v", row)
+	}
+	head := *testKeyedHead
+	head.PrimaryKey, head.NoPrimaryKey = nil, true
+	table.Head = &head
+	if err = storage.WriteTable(context.TODO(), table); err != nil {
+		t.Fatal(err)
+	}
+	if row := mr.HGet(table.Name, "1"); row != `{"level":1,"name":"b","reward":10,"type":2}` {
+		t.Errorf("row of index 1: %v", row)
+	}
+}
+
+func TestCompositeKeyIdentity(t *testing.T) {
+	head := &pb.TableHead{
+		Fields:     []string{"a", "b"},
+		Types:      []string{"string", "string"},
+		Descs:      []string{"", ""},
+		PrimaryKey: []string{"a", "b"},
+	}
+	table := &Table{Name: "pair_list", Head: head, Content: `[{"a":"x:y","b":"z"},{"a":"x","b":"y:z"}]`}
+	if violations := validateTable(table, nil); len(violations) != 0 {
+		t.Errorf("distinct keys are duplicate: %v", violations)
+	}
+	if changes, err := diffRows(table, table); err != nil || len(changes) != 0 {
+		t.Errorf("diff of the same table: %v, %v", changes, err)
+	}
+	sqlite := testStorages(t)["sqlite"]
+	if err := sqlite.WriteTable(context.TODO(), table); err != nil {
+		t.Errorf("sqlite: %v", err)
+	}
+	// the fields of the redis hash layout are the values joined by ":"
+	redisHash := testStorages(t)["redis_hash"]
+	if err := redisHash.WriteTable(context.TODO(), table); err == nil || !strings.Contains(err.Error(), "contain ':'") {
+		t.Errorf("redis hash, err: %v", err)
+	}
+}
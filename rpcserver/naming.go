@@ -0,0 +1,123 @@
+package rpcserver
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrTableNotAllowed is returned when a sheet is not in the allowlist of TableMapping
+	ErrTableNotAllowed = errors.New("table not allowed")
+	// ErrInvalidName is returned by sql storages for table or column names which are not identifiers
+	ErrInvalidName = errors.New("invalid name")
+)
+
+const (
+	// maxIdentifierLength is the max length of identifiers in every backend,
+	// postgres truncates identifiers longer than 63 characters
+	maxIdentifierLength = 63
+	// mysqlMaxIdentifierLength is the max length of mysql table and database names
+	mysqlMaxIdentifierLength = 64
+)
+
+// identifierRegexp matches names safe to be used as sql identifiers in every backend
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// TableMapping maps sheet names of excel2config to physical tables,
+// so config tables don't pollute the application schema
+type TableMapping struct {
+	// Database is where the tables and the schema and history tables of e2cdatabus are created,
+	// default is the database of the connection
+	Database string
+	// Prefix is prepended to the table names, e.g. "cfg_"
+	Prefix string
+	// Tables is an allowlist from sheet names to table names, the prefix is not applied to them.
+	// When it's not empty, sheets not in it are rejected with ErrTableNotAllowed.
+	Tables map[string]string
+}
+
+// tableName returns the physical table name of a sheet
+func (t TableMapping) tableName(sheet string) (string, error) {
+	if len(t.Tables) > 0 {
+		name, ok := t.Tables[sheet]
+		if !ok {
+			return "", ErrTableNotAllowed
+		}
+		return name, nil
+	}
+	return t.Prefix + sheet, nil
+}
+
+// sheetName returns the sheet name of a physical table, ok is false for tables not mapped
+func (t TableMapping) sheetName(table string) (sheet string, ok bool) {
+	if len(t.Tables) > 0 {
+		for sheet, name := range t.Tables {
+			if name == table {
+				return sheet, true
+			}
+		}
+		return "", false
+	}
+	if !strings.HasPrefix(table, t.Prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(table, t.Prefix), true
+}
+
+// checkIdentifier makes sure the name is a plain identifier, e.g. "item_list"
+func checkIdentifier(name string) error {
+	return checkIdentifierLength(name, maxIdentifierLength)
+}
+
+// checkIdentifierLength makes sure the name is a plain identifier of at most maxLength characters
+func checkIdentifierLength(name string, maxLength int) error {
+	if !identifierRegexp.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	if len(name) > maxLength {
+		return fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidName, name, maxLength)
+	}
+	return nil
+}
+
+// checkUnreserved makes sure the name is not reserved for the tables of e2cdatabus itself
+func checkUnreserved(name string) error {
+	if strings.HasPrefix(name, metaTablePrefix) {
+		return fmt.Errorf("%w: %q is reserved", ErrInvalidName, name)
+	}
+	return nil
+}
+
+// checkTableIdentifiers checks the table name and all the field names of the head
+func checkTableIdentifiers(tableName string, head *pb.TableHead) error {
+	return checkTableLength(tableName, maxIdentifierLength, head)
+}
+
+// checkTableLength checks the names like checkTableIdentifiers with the max length of the table name
+func checkTableLength(tableName string, maxLength int, head *pb.TableHead) error {
+	if err := checkIdentifierLength(tableName, maxLength); err != nil {
+		return err
+	}
+	// staged uploads are the only meta tables written by the Service, uploads can't take their names
+	if !strings.HasPrefix(tableName, stagedUploadPrefix) {
+		if err := checkUnreserved(tableName); err != nil {
+			return err
+		}
+	}
+	if len(head.GetFields()) == 0 {
+		return errors.New("empty table head")
+	}
+	if len(head.Types) != len(head.Fields) || len(head.Descs) != len(head.Fields) {
+		return errors.New("fields, types and descs of table head are not the same length")
+	}
+	for _, field := range head.Fields {
+		if err := checkIdentifier(field); err != nil {
+			return err
+		}
+	}
+	_, err := parseTableKeys(head)
+	return err
+}
@@ -0,0 +1,140 @@
+package rpcserver
+
+import (
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTableMapping(t *testing.T) {
+	prefix := TableMapping{Prefix: "cfg_"}
+	allowlist := TableMapping{Prefix: "cfg_", Tables: map[string]string{"item_list": "items"}}
+	cases := []struct {
+		mapping TableMapping
+		sheet   string
+		table   string
+		err     error
+	}{
+		{TableMapping{}, "item_list", "item_list", nil},
+		{prefix, "item_list", "cfg_item_list", nil},
+		{allowlist, "item_list", "items", nil},
+		{allowlist, "shop_list", "", ErrTableNotAllowed},
+	}
+	for _, c := range cases {
+		table, err := c.mapping.tableName(c.sheet)
+		if table != c.table || err != c.err {
+			t.Errorf("tableName(%s) of %+v: %s, %v", c.sheet, c.mapping, table, err)
+		}
+		if err != nil {
+			continue
+		}
+		if sheet, ok := c.mapping.sheetName(table); !ok || sheet != c.sheet {
+			t.Errorf("sheetName(%s) of %+v: %s, %v", table, c.mapping, sheet, ok)
+		}
+	}
+	if _, ok := prefix.sheetName("user"); ok {
+		t.Errorf("application table user is mapped")
+	}
+}
+
+func TestTableNameLength(t *testing.T) {
+	name := strings.Repeat("a", maxIdentifierLength)
+	if err := checkTableIdentifiers(name, testTable.Head); err != nil {
+		t.Fatal(err)
+	}
+	other := strings.Repeat("a", maxIdentifierLength-1) + "b"
+	for _, derived := range [][2]string{
+		{tempName(name), tempName(other)},
+		{stagedTableName(name), stagedTableName(other)},
+		{tempName(stagedTableName(name)), tempName(stagedTableName(other))},
+		{tempName(scratchTablePrefix + name), tempName(scratchTablePrefix + other)},
+		{fitIdentifier(name + "_bak"), fitIdentifier(other + "_bak")},
+	} {
+		if err := checkIdentifier(derived[0]); err != nil {
+			t.Errorf("name derived from the longest table name: %v", err)
+		}
+		if derived[0] == derived[1] {
+			t.Errorf("names derived from different tables collide: %s", derived[0])
+		}
+	}
+	if staged := stagedTableName(name); !strings.HasPrefix(staged, stagedTablePrefix) {
+		t.Errorf("staged table name loses the prefix: %s", staged)
+	}
+	if fitIdentifier("item_list_bak") != "item_list_bak" {
+		t.Errorf("short name is changed: %s", fitIdentifier("item_list_bak"))
+	}
+	if err := checkTableIdentifiers(name+"a", testTable.Head); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("too long table name, err: %v", err)
+	}
+	// mysql allows one more character
+	if err := checkTableLength(name+"a", mysqlMaxIdentifierLength, testTable.Head); err != nil {
+		t.Errorf("mysql table name of 64 characters, err: %v", err)
+	}
+	if err := checkIdentifier(strings.Repeat("a", 64)); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("too long identifier, err: %v", err)
+	}
+}
+
+func TestScratchTableNames(t *testing.T) {
+	// scratch tables of mysql dry runs are not listed as sheets while they exist
+	mapping := TableMapping{Prefix: "cfg_"}
+	names := userTables([]string{"cfg_item_list", tempName(scratchTablePrefix + "cfg_item_list"), stagedTableName("cfg_item_list")})
+	if len(names) != 1 {
+		t.Fatalf("user tables: %v", names)
+	}
+	if sheet, ok := mapping.sheetName(names[0]); !ok || sheet != "item_list" {
+		t.Errorf("sheet of %s: %s", names[0], sheet)
+	}
+}
+
+func TestMetaTableDatabase(t *testing.T) {
+	// the meta tables are kept in the database of the mapping, an attached database in sqlite
+	dir, err := ioutil.TempDir("", "e2cdatabus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	db, err := sqlx.Connect("sqlite3", filepath.Join(dir, "app.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	if _, err = db.Exec("ATTACH DATABASE " + sqliteQuoteLiteral(filepath.Join(dir, "config.db")) + " AS config"); err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := NewSqlSchemaStore(db, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	history, err := NewSqlHistoryStore(db, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	if err = schemas.SaveSchema(ctx, testTable.Name, testTable.Head); err != nil {
+		t.Fatal(err)
+	}
+	if err = history.AddVersion(ctx, newVersion(testTable, "fandy", 1), 0); err != nil {
+		t.Fatal(err)
+	}
+	var app, config []string
+	if err = db.Select(&app, "SELECT name FROM main.sqlite_master WHERE type = 'table'"); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Select(&config, "SELECT name FROM config.sqlite_master WHERE type = 'table' ORDER BY name"); err != nil {
+		t.Fatal(err)
+	}
+	if len(app) != 0 || !reflect.DeepEqual(config, []string{sqlHistoryTable, sqlSchemaTable}) {
+		t.Errorf("tables of the application: %v, of the config: %v", app, config)
+	}
+	if _, err = NewSqlSchemaStore(db, "config;"); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("invalid database, err: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package rpcserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/go-redis/redis"
+)
+
+const (
+	redisPubsubChannel = "config_refresh"
+)
+
+// Notification is sent after a config table is updated, so that services can hot reload it
+type Notification struct {
+	Table string `json:"table"`
+	// Version increases with each update of the table, it's the version number in the history
+	// store, and 0 if no history store is set or the version is not recorded
+	Version     int64  `json:"version"`
+	RowCount    int    `json:"rowCount"`
+	ContentHash string `json:"contentHash"`
+	DingtalkID  string `json:"dingtalkID"`
+	// ApprovedBy is the dingtalkID of who published the staged upload, it's empty for direct uploads
+	ApprovedBy string `json:"approvedBy,omitempty"`
+	// Timestamp is the update time in unix seconds
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Notifier sends the notification of an updated table
+type Notifier interface {
+	Notify(ctx context.Context, n *Notification) error
+}
+
+// RedisNotifier publishes notifications as json to a redis channel
+type RedisNotifier struct {
+	redis   redis.UniversalClient
+	channel string
+}
+
+// NewRedisNotifier return a Notifier publishing to channel, default channel is "config_refresh"
+func NewRedisNotifier(client redis.UniversalClient, channel string) *RedisNotifier {
+	if channel == "" {
+		channel = redisPubsubChannel
+	}
+	return &RedisNotifier{redis: client, channel: channel}
+}
+
+func (r *RedisNotifier) Notify(ctx context.Context, n *Notification) error {
+	bytes, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	return r.redis.Publish(r.channel, string(bytes)).Err()
+}
+
+// newNotification builds the notification of an uploaded version
+func newNotification(v *Version) *Notification {
+	n := &Notification{
+		Table:       v.Table,
+		Version:     v.Number,
+		ContentHash: v.ContentHash,
+		DingtalkID:  v.DingtalkID,
+		ApprovedBy:  v.ApprovedBy,
+		Timestamp:   v.Timestamp,
+	}
+	if rows, err := decodeContent(v.Content); err == nil {
+		n.RowCount = len(rows)
+	}
+	return n
+}
+
+// contentHash returns the sha256 of the content in hex
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
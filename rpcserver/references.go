@@ -0,0 +1,144 @@
+package rpcserver
+
+import (
+	"context"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"strings"
+)
+
+// checkReferences checks the references of the head refer to its fields
+func checkReferences(head *pb.TableHead, types []ColumnType) error {
+	fields := make(map[string]bool, len(head.Fields))
+	arrays := make(map[string]bool)
+	for index, field := range head.Fields {
+		fields[field] = true
+		arrays[field] = types[index].Array
+	}
+	for _, ref := range head.References {
+		if ref.GetTable() == "" {
+			return fmt.Errorf("reference of %s has no table", strings.Join(ref.GetFields(), ","))
+		}
+		key := "reference to " + ref.Table
+		if len(ref.Fields) == 0 {
+			return fmt.Errorf("%s has no fields", key)
+		}
+		if err := checkKeyFields(key, ref.Fields, fields); err != nil {
+			return err
+		}
+		if len(ref.TableFields) > 0 && len(ref.TableFields) != len(ref.Fields) {
+			return fmt.Errorf("%s has %d fields and %d table fields", key, len(ref.Fields), len(ref.TableFields))
+		}
+		for _, field := range ref.Fields {
+			if arrays[field] && len(ref.Fields) > 1 {
+				return fmt.Errorf("%s: array field %q can't be part of a composite reference", key, field)
+			}
+		}
+	}
+	return nil
+}
+
+// validateReferences checks the references of the table against the referenced tables
+// stored in the first storage, a table referencing itself is checked against its own content
+func (s *Service) validateReferences(ctx context.Context, table *Table) (violations []*pb.Violation, err error) {
+	if len(table.Head.References) == 0 {
+		return
+	}
+	content, err := decodeContent(table.Content)
+	if err != nil {
+		return
+	}
+	for _, ref := range table.Head.References {
+		var keys map[string]bool
+		keys, err = s.referencedKeys(ctx, table, content, ref)
+		if err == ErrTableNotFound {
+			err = nil
+			violations = append(violations, &pb.Violation{
+				Column: strings.Join(ref.Fields, ","),
+				Reason: "referenced table " + ref.Table + " not found",
+			})
+			continue
+		}
+		if err != nil {
+			return
+		}
+		violations = append(violations, checkReferenceRows(content, ref, keys)...)
+	}
+	return
+}
+
+// referencedKeys returns the keyIdentity of the referenced fields of each row in the referenced table
+func (s *Service) referencedKeys(ctx context.Context, table *Table, content []map[string]interface{}, ref *pb.TableReference) (keys map[string]bool, err error) {
+	head, rows := table.Head, content
+	if ref.Table != table.Name {
+		if len(s.storages) == 0 {
+			return nil, ErrTableNotFound
+		}
+		var referenced *Table
+		referenced, err = s.storages[0].storage.ReadTable(ctx, ref.Table)
+		if err != nil {
+			return
+		}
+		if rows, err = decodeContent(referenced.Content); err != nil {
+			return
+		}
+		head = referenced.Head
+		if s.schemas != nil {
+			if saved, loadErr := s.schemas.LoadSchema(ctx, ref.Table); loadErr == nil {
+				head = saved
+			}
+		}
+	}
+	tableFields := ref.TableFields
+	if len(tableFields) == 0 {
+		if head == nil {
+			return nil, fmt.Errorf("the primary key of %s is unknown, declare the table fields of the reference", ref.Table)
+		}
+		var headKeys tableKeys
+		if headKeys, err = parseTableKeys(head); err != nil {
+			return
+		}
+		if tableFields = headKeys.PrimaryKey; len(tableFields) == 0 {
+			return nil, fmt.Errorf("%s has no primary key, declare the table fields of the reference", ref.Table)
+		}
+		if len(tableFields) != len(ref.Fields) {
+			return nil, fmt.Errorf("reference to %s has %d fields but its primary key has %d", ref.Table, len(ref.Fields), len(tableFields))
+		}
+	}
+	keys = make(map[string]bool, len(rows))
+	for _, row := range rows {
+		keys[keyIdentity(row, tableFields)] = true
+	}
+	return
+}
+
+// checkReferenceRows reports the rows whose values of the reference fields are not in keys
+func checkReferenceRows(content []map[string]interface{}, ref *pb.TableReference, keys map[string]bool) (violations []*pb.Violation) {
+	column := strings.Join(ref.Fields, ",")
+	for index, row := range content {
+		values := []map[string]interface{}{row}
+		if elems, ok := row[ref.Fields[0]].([]interface{}); ok && len(ref.Fields) == 1 {
+			values = values[:0]
+			for _, elem := range elems {
+				values = append(values, map[string]interface{}{ref.Fields[0]: elem})
+			}
+		}
+		for _, value := range values {
+			hasNull := false
+			for _, field := range ref.Fields {
+				hasNull = hasNull || value[field] == nil
+			}
+			if hasNull {
+				continue
+			}
+			if !keys[keyIdentity(value, ref.Fields)] {
+				violations = append(violations, &pb.Violation{
+					Row:    int32(index + 1),
+					Column: column,
+					Reason: fmt.Sprintf("%s is not found in %s", rowKey(value, ref.Fields), ref.Table),
+				})
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,72 @@
+package rpcserver
+
+import (
+	"context"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"reflect"
+	"testing"
+)
+
+func TestReferences(t *testing.T) {
+	s := NewService()
+	s.SetStorage(NewMemoryStorage())
+	ctx := context.TODO()
+	dropReq := &pb.UpdateConfigReq{
+		Name: "drop_list",
+		Head: &pb.TableHead{
+			Fields:   []string{"id", "itemID", "extraItems", "next"},
+			Types:    []string{"int", "int", "int[]", "int"},
+			Descs:    []string{"", "", "", ""},
+			Nullable: []string{"next"},
+			References: []*pb.TableReference{
+				{Fields: []string{"itemID"}, Table: testTable.Name},
+				{Fields: []string{"extraItems"}, Table: testTable.Name, TableFields: []string{"sid"}},
+				{Fields: []string{"next"}, Table: "drop_list"},
+			},
+		},
+		Content: `[{"extraItems":[1,2],"id":1,"itemID":1,"next":2},{"extraItems":[3],"id":2,"itemID":4,"next":3}]`,
+	}
+	resp, err := s.UpdateConfig(ctx, dropReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != StatusInvalid || len(resp.Violations) != 3 || resp.Violations[0].Reason != "referenced table item_list not found" {
+		t.Errorf("UpdateConfig without item_list: %v", resp)
+	}
+
+	if _, err = s.UpdateConfig(ctx, testUpdateReq); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = s.UpdateConfig(ctx, dropReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*pb.Violation{
+		{Row: 2, Column: "itemID", Reason: "4 is not found in item_list"},
+		{Row: 2, Column: "extraItems", Reason: "3 is not found in item_list"},
+		{Row: 2, Column: "next", Reason: "3 is not found in drop_list"},
+	}
+	if !reflect.DeepEqual(resp.Violations, expected) {
+		t.Errorf("violations: %v", resp.Violations)
+	}
+
+	dropReq.Content = `[{"extraItems":[1,2],"id":1,"itemID":1,"next":2},{"extraItems":[],"id":2,"itemID":2}]`
+	resp, err = s.UpdateConfig(ctx, dropReq)
+	if err != nil || resp.Status != StatusOK {
+		t.Errorf("UpdateConfig of valid references: %v, %v", resp, err)
+	}
+
+	for _, ref := range []*pb.TableReference{
+		{Fields: []string{"itemID"}},
+		{Table: "item_list"},
+		{Fields: []string{"level"}, Table: "item_list"},
+		{Fields: []string{"itemID"}, Table: "item_list", TableFields: []string{"sid", "type"}},
+		{Fields: []string{"itemID", "extraItems"}, Table: "item_list", TableFields: []string{"sid", "type"}},
+	} {
+		head := *dropReq.Head
+		head.References = []*pb.TableReference{ref}
+		if violations := validateTable(&Table{Name: dropReq.Name, Head: &head, Content: `[]`}, nil); len(violations) != 1 {
+			t.Errorf("reference %v: %v", ref, violations)
+		}
+	}
+}
@@ -20,20 +20,14 @@ type Conf struct {
 	AppSecret string
 }
 
-func Start(conf Conf, service pb.DatabusServer, handlers ...grpc.UnaryServerInterceptor) error {
-	if conf.Port == 0 {
-		conf.Port = 10000
-	}
+// NewServer return a grpc server with the service registered and auth interceptors installed,
+// it's up to the caller to serve it on a listener
+func NewServer(conf Conf, service pb.DatabusServer, handlers ...grpc.UnaryServerInterceptor) (*grpc.Server, error) {
 	if conf.AppKey == "" || conf.AppSecret == "" {
-		return errors.New("invalid appKey or appSecret")
+		return nil, errors.New("invalid appKey or appSecret")
 	}
 	if service == nil {
-		return errors.New("invalid service")
-	}
-	lis, err := net.Listen("tcp", ":"+strconv.Itoa(conf.Port))
-	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
-		return err
+		return nil, errors.New("invalid service")
 	}
 	opt := make([]grpc.ServerOption, 0)
 	authService := auth.New(conf.AppKey, conf.AppSecret)
@@ -42,6 +36,22 @@ func Start(conf Conf, service pb.DatabusServer, handlers ...grpc.UnaryServerInte
 	opt = append(opt, grpc.UnaryInterceptor(authService.Interceptor))
 	s := grpc.NewServer(opt...)
 	pb.RegisterDatabusServer(s, service)
+	return s, nil
+}
+
+func Start(conf Conf, service pb.DatabusServer, handlers ...grpc.UnaryServerInterceptor) error {
+	if conf.Port == 0 {
+		conf.Port = 10000
+	}
+	s, err := NewServer(conf, service, handlers...)
+	if err != nil {
+		return err
+	}
+	lis, err := net.Listen("tcp", ":"+strconv.Itoa(conf.Port))
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+		return err
+	}
 	go func() {
 		log.Println("e2cdatabus started")
 		if err := s.Serve(lis); err != nil {
@@ -0,0 +1,165 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rules are server side column rules keyed by table name and field,
+// they apply together with the rules in the head of uploaded tables
+type Rules map[string]map[string]*pb.ColumnRule
+
+// LoadRules reads rules from a json file, e.g.
+// {"item_list": {"price": {"min": "0", "max": "9999"}, "name": {"nonEmpty": true, "maxLength": 32}}}
+func LoadRules(path string) (rules Rules, err error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(bytes, &rules)
+	return
+}
+
+// columnRule is a compiled pb.ColumnRule
+type columnRule struct {
+	min, max *float64
+	pattern  *regexp.Regexp
+	// patternText is the pattern as declared, without the anchors
+	patternText string
+	maxLength   int
+	oneOf       map[string]bool
+	values      string
+	nonEmpty    bool
+}
+
+// compileRules compiles the rules of the head and the server side rules of the table,
+// it returns the rules of each field
+func compileRules(head *pb.TableHead, types []ColumnType, serverRules map[string]*pb.ColumnRule) (rules map[string][]*columnRule, err error) {
+	fieldTypes := make(map[string]ColumnType, len(head.Fields))
+	for index, field := range head.Fields {
+		fieldTypes[field] = types[index]
+	}
+	rules = make(map[string][]*columnRule)
+	for _, ruleSet := range []map[string]*pb.ColumnRule{head.Rules, serverRules} {
+		for field, rule := range ruleSet {
+			ct, ok := fieldTypes[field]
+			if !ok {
+				return nil, fmt.Errorf("rules: unknown field %q", field)
+			}
+			compiled, err := compileRule(ct, rule)
+			if err != nil {
+				return nil, fmt.Errorf("rules of %s: %w", field, err)
+			}
+			rules[field] = append(rules[field], compiled)
+		}
+	}
+	return
+}
+
+func compileRule(ct ColumnType, rule *pb.ColumnRule) (compiled *columnRule, err error) {
+	compiled = &columnRule{maxLength: int(rule.GetMaxLength()), nonEmpty: rule.GetNonEmpty()}
+	if rule.GetMin() != "" || rule.GetMax() != "" {
+		if ct.Base != TypeInt && ct.Base != TypeFloat && ct.Base != TypeDouble {
+			return nil, fmt.Errorf("min and max need a number column, not %s", ct)
+		}
+		if compiled.min, err = parseLimit(rule.Min); err == nil {
+			compiled.max, err = parseLimit(rule.Max)
+		}
+		if err != nil {
+			return
+		}
+		if compiled.min != nil && compiled.max != nil && *compiled.min > *compiled.max {
+			return nil, fmt.Errorf("min %s is greater than max %s", rule.Min, rule.Max)
+		}
+	}
+	if rule.GetPattern() != "" {
+		compiled.patternText = rule.Pattern
+		compiled.pattern, err = regexp.Compile(`^(?:` + rule.Pattern + `)$`)
+		if err != nil {
+			return
+		}
+	}
+	if compiled.maxLength < 0 {
+		return nil, fmt.Errorf("negative max length %d", compiled.maxLength)
+	}
+	if len(rule.GetOneOf()) > 0 {
+		compiled.oneOf = make(map[string]bool, len(rule.OneOf))
+		for _, value := range rule.OneOf {
+			compiled.oneOf[value] = true
+		}
+		compiled.values = strings.Join(rule.OneOf, ",")
+	}
+	return
+}
+
+// parseLimit parses min or max, nil means no limit
+func parseLimit(text string) (*float64, error) {
+	if text == "" {
+		return nil, nil
+	}
+	limit, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid limit %q", text)
+	}
+	return &limit, nil
+}
+
+// check checks a cell which is already known to be a value of the column type
+func (r *columnRule) check(ct ColumnType, cell interface{}) error {
+	if cell == nil {
+		if r.nonEmpty {
+			return errors.New("empty value")
+		}
+		return nil
+	}
+	if elems, ok := cell.([]interface{}); ok && ct.Array {
+		if r.nonEmpty && len(elems) == 0 {
+			return errors.New("empty array")
+		}
+		if r.maxLength > 0 && len(elems) > r.maxLength {
+			return fmt.Errorf("%d elements are more than max length %d", len(elems), r.maxLength)
+		}
+		for index, elem := range elems {
+			if err := r.checkValue(elem); err != nil {
+				return fmt.Errorf("element %d: %w", index, err)
+			}
+		}
+		return nil
+	}
+	text, _ := cellValue(cell).(string)
+	if r.nonEmpty && text == "" {
+		return errors.New("empty value")
+	}
+	if length := utf8.RuneCountInString(text); r.maxLength > 0 && length > r.maxLength {
+		return fmt.Errorf("%d characters are more than max length %d", length, r.maxLength)
+	}
+	return r.checkValue(cell)
+}
+
+// checkValue checks the range, pattern and values of a scalar cell
+func (r *columnRule) checkValue(cell interface{}) error {
+	if number, ok := cell.(json.Number); ok {
+		value, _ := number.Float64()
+		if r.min != nil && value < *r.min {
+			return fmt.Errorf("%s is less than min %v", number, *r.min)
+		}
+		if r.max != nil && value > *r.max {
+			return fmt.Errorf("%s is greater than max %v", number, *r.max)
+		}
+	}
+	text, _ := cellValue(cell).(string)
+	if r.pattern != nil && !r.pattern.MatchString(text) {
+		return fmt.Errorf("%s doesn't match pattern %s", cellText(cell), r.patternText)
+	}
+	if r.oneOf != nil && !r.oneOf[text] {
+		return fmt.Errorf("%s is not one of %s", cellText(cell), r.values)
+	}
+	return nil
+}
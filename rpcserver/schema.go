@@ -0,0 +1,216 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"github.com/go-redis/redis"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// metaTablePrefix is reserved for the tables of e2cdatabus itself in sql storages
+	metaTablePrefix = "e2cdatabus_"
+	// sqlSchemaTable keeps the head of every table in sql storages
+	sqlSchemaTable = metaTablePrefix + "schema"
+	// redisSchemaKey is a hash of table name to the json head
+	redisSchemaKey = "e2cdatabus:schema"
+)
+
+// errSchemaNotSaved is wrapped in the error of Service.apply
+// when the table is written but its head is not saved
+var errSchemaNotSaved = errors.New("schema is not saved")
+
+// SchemaStore persists the head of each uploaded table exactly as it was sent,
+// storages lose part of it, e.g. sqlite has no column comments and redis keeps no head at all
+type SchemaStore interface {
+	SaveSchema(ctx context.Context, name string, head *pb.TableHead) error
+	// LoadSchema returns ErrTableNotFound if no head of the table is saved
+	LoadSchema(ctx context.Context, name string) (*pb.TableHead, error)
+}
+
+// MemorySchemaStore keeps the last saved head of each table in a map,
+// the heads are kept as they are passed in rather than copied
+type MemorySchemaStore struct {
+	mu    sync.RWMutex
+	heads map[string]*pb.TableHead
+}
+
+// NewMemorySchemaStore return a SchemaStore backed by memory
+func NewMemorySchemaStore() *MemorySchemaStore {
+	return &MemorySchemaStore{heads: make(map[string]*pb.TableHead)}
+}
+
+func (m *MemorySchemaStore) SaveSchema(ctx context.Context, name string, head *pb.TableHead) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.heads[name] = head
+	return nil
+}
+
+func (m *MemorySchemaStore) LoadSchema(ctx context.Context, name string) (*pb.TableHead, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	head, ok := m.heads[name]
+	if !ok {
+		return nil, ErrTableNotFound
+	}
+	return head, nil
+}
+
+// SqlSchemaStore keeps heads as json in the table e2cdatabus_schema,
+// a row of each table which is replaced on every save
+type SqlSchemaStore struct {
+	db *sqlx.DB
+	// table is the schema table qualified with its database
+	table string
+}
+
+// NewSqlSchemaStore return a SchemaStore backed by db, the schema table is created in database
+// if not exists, the default database of the connection is used if database is empty
+func NewSqlSchemaStore(db *sqlx.DB, database string) (*SqlSchemaStore, error) {
+	table, err := metaTableName(db, database, sqlSchemaTable)
+	if err != nil {
+		return nil, err
+	}
+	_, err = db.Exec("CREATE TABLE IF NOT EXISTS " + table + " (" +
+		"name VARCHAR(64) NOT NULL PRIMARY KEY, head TEXT NOT NULL, updated_at BIGINT NOT NULL)")
+	if err != nil {
+		return nil, err
+	}
+	return &SqlSchemaStore{db: db, table: table}, nil
+}
+
+func (q *SqlSchemaStore) SaveSchema(ctx context.Context, name string, head *pb.TableHead) (err error) {
+	bytes, err := json.Marshal(head)
+	if err != nil {
+		return
+	}
+	// delete and insert instead of an upsert, whose syntax differs between databases
+	tx, err := q.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	_, err = tx.ExecContext(ctx, q.db.Rebind("DELETE FROM "+q.table+" WHERE name = ?"), name)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, q.db.Rebind("INSERT INTO "+q.table+" (name, head, updated_at) VALUES (?, ?, ?)"),
+			name, string(bytes), time.Now().Unix())
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		tx.Rollback()
+	}
+	return
+}
+
+func (q *SqlSchemaStore) LoadSchema(ctx context.Context, name string) (head *pb.TableHead, err error) {
+	var heads []string
+	err = q.db.SelectContext(ctx, &heads, q.db.Rebind("SELECT head FROM "+q.table+" WHERE name = ?"), name)
+	if err != nil {
+		return
+	}
+	if len(heads) == 0 {
+		err = ErrTableNotFound
+		return
+	}
+	head = &pb.TableHead{}
+	err = json.Unmarshal([]byte(heads[0]), head)
+	return
+}
+
+// RedisSchemaStore keeps heads as json in the hash e2cdatabus:schema
+type RedisSchemaStore struct {
+	redis  redis.UniversalClient
+	prefix string
+}
+
+// NewRedisSchemaStore return a SchemaStore backed by redis, prefix is prepended to the key
+func NewRedisSchemaStore(client redis.UniversalClient, prefix string) *RedisSchemaStore {
+	return &RedisSchemaStore{redis: client, prefix: prefix}
+}
+
+func (r *RedisSchemaStore) SaveSchema(ctx context.Context, name string, head *pb.TableHead) error {
+	bytes, err := json.Marshal(head)
+	if err != nil {
+		return err
+	}
+	return r.redis.HSet(r.prefix+redisSchemaKey, name, string(bytes)).Err()
+}
+
+func (r *RedisSchemaStore) LoadSchema(ctx context.Context, name string) (head *pb.TableHead, err error) {
+	bytes, err := r.redis.HGet(r.prefix+redisSchemaKey, name).Bytes()
+	if err == redis.Nil {
+		err = ErrTableNotFound
+	}
+	if err != nil {
+		return
+	}
+	head = &pb.TableHead{}
+	err = json.Unmarshal(bytes, head)
+	return
+}
+
+// schemaHead returns the head in the schema store if it has the same fields as the columns
+// rebuilt by a storage and the storage declares the same column types for it, so cells are
+// decoded by the declared types. headType returns the type of the column a storage declares for
+// a type, as it's rebuilt in columns. Otherwise, e.g. the table was written by other means,
+// the saved head is stale or no schema store is set, the rebuilt head is returned.
+func schemaHead(ctx context.Context, schemas SchemaStore, name string, columns *pb.TableHead, headType func(ColumnType) string) (*pb.TableHead, error) {
+	if schemas == nil {
+		return columns, nil
+	}
+	head, err := schemas.LoadSchema(ctx, name)
+	if err == ErrTableNotFound {
+		return columns, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(head.Fields) != len(columns.Fields) || len(head.Types) != len(head.Fields) {
+		return columns, nil
+	}
+	types, err := parseHeadTypes(head)
+	if err != nil {
+		return columns, nil
+	}
+	for index, field := range head.Fields {
+		if columns.Fields[index] != field || headType(types[index]) != columns.Types[index] {
+			return columns, nil
+		}
+	}
+	return head, nil
+}
+
+// metaTableName returns the meta table qualified with database if it's set
+func metaTableName(db *sqlx.DB, database, table string) (string, error) {
+	if database == "" {
+		return table, nil
+	}
+	if db.DriverName() == "mysql" {
+		if err := checkIdentifierLength(database, mysqlMaxIdentifierLength); err != nil {
+			return "", err
+		}
+		return mysqlQuoteIdentifier(database) + "." + table, nil
+	}
+	if err := checkIdentifier(database); err != nil {
+		return "", err
+	}
+	return sqliteQuoteIdentifier(database) + "." + table, nil
+}
+
+// userTables filters out the meta tables from table names of a storage
+func userTables(names []string) []string {
+	res := names[:0]
+	for _, name := range names {
+		if !strings.HasPrefix(name, metaTablePrefix) {
+			res = append(res, name)
+		}
+	}
+	return res
+}
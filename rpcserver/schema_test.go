@@ -0,0 +1,177 @@
+package rpcserver
+
+import (
+	"context"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"github.com/go-redis/redis"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchemaStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "e2cdatabus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := NewService()
+	if err = s.SetSqliteConnect(filepath.Join(dir, "config.db")); err != nil {
+		t.Fatal(err)
+	}
+	sqlSchemas := s.schemas
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	stores := map[string]SchemaStore{
+		"memory": NewMemorySchemaStore(),
+		"sql":    sqlSchemas,
+		"redis":  NewRedisSchemaStore(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "config:"),
+		"file":   NewFileStorage(dir),
+	}
+	ctx := context.TODO()
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			if _, err := store.LoadSchema(ctx, testTable.Name); err != ErrTableNotFound {
+				t.Fatalf("load missing schema, err: %v", err)
+			}
+			for i := 0; i < 2; i++ {
+				if err := store.SaveSchema(ctx, testTable.Name, testTable.Head); err != nil {
+					t.Fatal(err)
+				}
+			}
+			head, err := store.LoadSchema(ctx, testTable.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(head, testTable.Head) {
+				t.Errorf("head is diffrent, %v", head)
+			}
+		})
+	}
+}
+
+func TestGetSchema(t *testing.T) {
+	dir, err := ioutil.TempDir("", "e2cdatabus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := NewService()
+	if err = s.SetSqliteConnect(filepath.Join(dir, "config.db")); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+	resp, err := s.GetSchema(ctx, &pb.GetSchemaReq{Name: testTable.Name})
+	if err != nil || resp.Head != nil {
+		t.Fatalf("GetSchema of missing table: %v, %v", resp, err)
+	}
+	if _, err = s.UpdateConfig(ctx, testUpdateReq); err != nil {
+		t.Fatal(err)
+	}
+	// sqlite has no column comments, the descs come from the schema store
+	resp, err = s.GetSchema(ctx, &pb.GetSchemaReq{Name: testTable.Name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resp.Head, testTable.Head) {
+		t.Errorf("head is diffrent, %v", resp.Head)
+	}
+	names, err := s.storages[0].storage.ListTables(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != testTable.Name {
+		t.Errorf("ListTables: %v", names)
+	}
+	if _, err = s.UpdateConfig(ctx, &pb.UpdateConfigReq{Name: sqlSchemaTable, Head: testTable.Head, Content: `[]`}); err == nil {
+		t.Error("UpdateConfig of a reserved table succeed")
+	}
+}
+
+func TestSchemaHead(t *testing.T) {
+	ctx := context.TODO()
+	// columns rebuilt from mysql lose the keys and the element types of arrays
+	columns := &pb.TableHead{Fields: []string{"sid", "tags"}, Types: []string{"int", "json"}, Descs: []string{"", ""}}
+	declared := &pb.TableHead{Fields: []string{"sid", "tags"}, Types: []string{"int", "int[]"}, Descs: []string{"", ""},
+		PrimaryKey: []string{"sid"}, Nullable: []string{"tags"}}
+	schemas := NewMemorySchemaStore()
+	schemas.SaveSchema(ctx, "item_list", declared)
+	schemas.SaveSchema(ctx, "shop_list", &pb.TableHead{Fields: []string{"id"}, Types: []string{"int"}, Descs: []string{""}})
+	// the saved head is stale, e.g. the write to mysql failed under WriteBestEffort
+	schemas.SaveSchema(ctx, "stale_list", &pb.TableHead{Fields: []string{"sid", "tags"}, Types: []string{"string", "int[]"}, Descs: []string{"", ""}})
+	cases := []struct {
+		schemas SchemaStore
+		name    string
+		head    *pb.TableHead
+	}{
+		{nil, "item_list", columns},
+		{schemas, "item_list", declared},
+		{schemas, "missing", columns},
+		{schemas, "shop_list", columns},
+		{schemas, "stale_list", columns},
+	}
+	mysql := NewMysqlStorage(nil)
+	for _, c := range cases {
+		if head, err := schemaHead(ctx, c.schemas, c.name, columns, mysql.declaredHeadType); err != nil || head != c.head {
+			t.Errorf("schemaHead of %s: %v, %v", c.name, head, err)
+		}
+	}
+
+	schemas2 := NewMemorySchemaStore()
+	s := NewService()
+	s.SetSchemaStore(schemas)
+	s.AddStorage("mysql", mysql)
+	if mysql.schemas != schemas {
+		t.Error("schema store is not set on the added mysql storage")
+	}
+	s.SetSchemaStore(schemas2)
+	if mysql.schemas != schemas2 {
+		t.Error("schema store is not set on the mysql storage")
+	}
+}
+
+// failSchemaStore fails to save heads
+type failSchemaStore struct {
+	*MemorySchemaStore
+}
+
+func (f failSchemaStore) SaveSchema(ctx context.Context, name string, head *pb.TableHead) error {
+	return errors.New("save failed")
+}
+
+func TestSchemaFailed(t *testing.T) {
+	storage := NewMemoryStorage()
+	s := NewService()
+	s.SetStorage(storage)
+	s.SetSchemaStore(failSchemaStore{NewMemorySchemaStore()})
+	s.SetHistoryStore(NewMemoryHistoryStore())
+	ctx := context.TODO()
+	resp, err := s.UpdateConfig(ctx, testUpdateReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != StatusSchemaFailed || resp.Version != 1 || !strings.Contains(resp.ErrMsg, "save failed") {
+		t.Errorf("UpdateConfig resp: %v", resp)
+	}
+	if table, _ := storage.ReadTable(ctx, testTable.Name); table == nil || table.Content != testTable.Content {
+		t.Errorf("table is not written: %v", table)
+	}
+
+	// the version failure is reported with it
+	s.SetHistoryStore(failHistoryStore{NewMemoryHistoryStore()})
+	resp, err = s.UpdateConfig(ctx, testUpdateReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != StatusVersionFailed || !strings.Contains(resp.ErrMsg, "add failed") || !strings.Contains(resp.ErrMsg, "save failed") {
+		t.Errorf("UpdateConfig resp: %v", resp)
+	}
+}
@@ -0,0 +1,158 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/alicebob/miniredis/v2"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"github.com/go-redis/redis"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var testUpdateReq = &pb.UpdateConfigReq{
+	Name:       testTable.Name,
+	Head:       testTable.Head,
+	Content:    testTable.Content,
+	DingtalkID: "fandy",
+}
+
+func TestNotify(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	pubsub := client.Subscribe("item_refresh")
+	defer pubsub.Close()
+	if _, err = pubsub.Receive(); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewService()
+	s.SetStorage(NewMemoryStorage())
+	s.SetNotifier(NewRedisNotifier(client, "item_refresh"))
+	resp, err := s.UpdateConfig(context.TODO(), testUpdateReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// no version is recorded without a history store
+	if resp.Version != 0 {
+		t.Errorf("version without a history store: %d", resp.Version)
+	}
+	select {
+	case msg := <-pubsub.Channel():
+		var n Notification
+		if err = json.Unmarshal([]byte(msg.Payload), &n); err != nil {
+			t.Fatal(err)
+		}
+		if n.Table != testTable.Name || n.Version != 0 || n.RowCount != 2 || n.DingtalkID != "fandy" || len(n.ContentHash) != 64 {
+			t.Errorf("notification: %s", msg.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no notification")
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "e2cdatabus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	notifier := &recordNotifier{}
+	s := NewService()
+	if err = s.SetSqliteConnect(filepath.Join(dir, "config.db")); err != nil {
+		t.Fatal(err)
+	}
+	s.AddStorage("memory", NewMemoryStorage())
+	s.SetNotifier(notifier)
+	ctx := context.TODO()
+	if _, err = s.UpdateConfig(ctx, testUpdateReq); err != nil {
+		t.Fatal(err)
+	}
+	req := *testUpdateReq
+	req.Content = `[{"name":"名称1","sid":1,"type":2}]`
+	req.DryRun = true
+	resp, err := s.UpdateConfig(ctx, &req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != StatusOK || resp.Version != 0 || len(resp.Sinks) != 1 || resp.Sinks[0].Name != "sqlite" ||
+		len(resp.Rows) != 2 || resp.Rows[0].Kind != ChangeModified || resp.Rows[1].Kind != ChangeRemoved {
+		t.Errorf("dry run resp: %v", resp)
+	}
+	if table, _ := s.storages[0].storage.ReadTable(ctx, testTable.Name); table.Content != testTable.Content {
+		t.Errorf("table is written by dry run: %v", table.Content)
+	}
+	if len(notifier.notifications) != 1 {
+		t.Errorf("dry run is notified: %v", notifier.notifications)
+	}
+
+	// a write failing in the storage
+	storage := s.storages[0].storage.(DryRunner)
+	dup := &Table{Name: "dup_list", Head: testTable.Head, Content: `[{"name":"a","sid":1,"type":1},{"name":"b","sid":1,"type":1}]`}
+	if err = storage.DryRunTable(ctx, dup); err == nil {
+		t.Error("dry run of duplicate keys succeed")
+	}
+	dup.Content = `[{"name":"a","sid":1,"type":1}]`
+	if err = storage.DryRunTable(ctx, dup); err != nil {
+		t.Fatal(err)
+	}
+	if names, _ := s.storages[0].storage.ListTables(ctx); len(names) != 1 {
+		t.Errorf("tables are left by dry run: %v", names)
+	}
+}
+
+func TestRedisNotifier(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	dir, err := ioutil.TempDir("", "e2cdatabus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	pubsub := redis.NewClient(&redis.Options{Addr: mr.Addr()}).Subscribe("config_refresh")
+	defer pubsub.Close()
+	if _, err = pubsub.Receive(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a sql storage with notifications only from redis, like SetMyqlConnect and SetRedisNotifier
+	s := NewService()
+	if err = s.SetSqliteConnect(filepath.Join(dir, "config.db")); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.SetRedisNotifier(RedisConf{Addrs: []string{mr.Addr()}}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := s.UpdateConfig(context.TODO(), testUpdateReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Sinks) != 1 || resp.Sinks[0].Name != "sqlite" {
+		t.Errorf("sinks: %v", resp.Sinks)
+	}
+	select {
+	case msg := <-pubsub.Channel():
+		var n Notification
+		if err = json.Unmarshal([]byte(msg.Payload), &n); err != nil {
+			t.Fatal(err)
+		}
+		if n.Table != testTable.Name || n.Version != resp.Version {
+			t.Errorf("notification: %s", msg.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no notification")
+	}
+	if keys := mr.Keys(); len(keys) != 0 {
+		t.Errorf("tables are written to redis: %v", keys)
+	}
+}
@@ -0,0 +1,156 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"log"
+)
+
+// stagedHead is the head of the table an upload is kept as in the staging storage, so any
+// Storage keeps the uploader with it. The head and the content are kept as uploaded.
+var stagedHead = &pb.TableHead{
+	Fields: []string{"dingtalkID", "head", "content"},
+	Types:  []string{TypeString, TypeString, TypeString},
+	Descs:  []string{"uploader", "json head", "json content"},
+}
+
+// stagedUpload is the row of stagedHead
+type stagedUpload struct {
+	DingtalkID string `json:"dingtalkID"`
+	Head       string `json:"head"`
+	Content    string `json:"content"`
+}
+
+// PublishConfig publishes the upload staged by UpdateConfig with stage set, the storages
+// implementing Stager swap their staged table in and the others are written. It's recorded
+// as a new version of the uploader approved by req.DingtalkID, and notified like an upload.
+// If it fails, the upload must be staged again.
+func (s *Service) PublishConfig(ctx context.Context, req *pb.PublishConfigReq) (resp *pb.PublishConfigResp, err error) {
+	resp = &pb.PublishConfigResp{Status: StatusOK}
+	staged, err := s.staging.ReadTable(ctx, stagedUploadName(req.Name))
+	if err == ErrTableNotFound {
+		resp.Status = StatusFailed
+		resp.ErrMsg = fmt.Sprintf("nothing staged for %s", req.Name)
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	table, uploader, err := unstageTable(req.Name, staged)
+	if err != nil {
+		return
+	}
+	resp.Sinks, resp.Version, err = s.apply(ctx, table, uploader, req.DingtalkID, publishTable)
+	resp.Status, resp.ErrMsg, err = appliedStatus(resp.Sinks, err)
+	if err != nil {
+		return
+	}
+	if deleteErr := s.staging.DeleteTable(ctx, stagedUploadName(req.Name)); deleteErr != nil {
+		log.Printf("delete staged %s failed: %v", req.Name, deleteErr)
+	}
+	return
+}
+
+// DiscardStaged drops the staged upload of a table. If the staging storage doesn't keep it,
+// e.g. the in memory one after a restart, the tables staged by the Stagers are dropped anyway.
+func (s *Service) DiscardStaged(ctx context.Context, req *pb.DiscardStagedReq) (resp *pb.DiscardStagedResp, err error) {
+	resp = &pb.DiscardStagedResp{Status: StatusOK}
+	_, err = s.staging.ReadTable(ctx, stagedUploadName(req.Name))
+	if err == ErrTableNotFound {
+		if err = s.discardStaged(ctx, req.Name); err != nil {
+			return
+		}
+		resp.Status = StatusFailed
+		resp.ErrMsg = fmt.Sprintf("nothing staged for %s", req.Name)
+		return
+	}
+	if err == nil {
+		err = s.discardStaged(ctx, req.Name)
+	}
+	return
+}
+
+// stage writes the table into the staging slot of every Stager, then keeps it with its uploader
+// in the staging storage. If any of them fails, what was staged for the table is discarded.
+func (s *Service) stage(ctx context.Context, table *Table, dingtalkID string) (sinks []*pb.SinkStatus, err error) {
+	for _, ns := range s.storages {
+		stager, ok := ns.storage.(Stager)
+		if !ok {
+			continue
+		}
+		if err = stager.StageTable(ctx, table); err != nil {
+			err = fmt.Errorf("%s: %w", ns.name, err)
+			break
+		}
+		sinks = append(sinks, &pb.SinkStatus{Name: ns.name, Status: StatusOK})
+	}
+	var staged *Table
+	if err == nil {
+		staged, err = stageTable(table, dingtalkID)
+	}
+	if err == nil {
+		err = s.staging.WriteTable(ctx, staged)
+	}
+	if err != nil {
+		if discardErr := s.discardStaged(ctx, table.Name); discardErr != nil {
+			log.Printf("discard staged %s failed: %v", table.Name, discardErr)
+		}
+		sinks = nil
+	}
+	return
+}
+
+// discardStaged drops the staged table of every Stager and the staging storage
+func (s *Service) discardStaged(ctx context.Context, name string) (err error) {
+	for _, ns := range s.storages {
+		stager, ok := ns.storage.(Stager)
+		if !ok {
+			continue
+		}
+		if err = stager.DiscardStaged(ctx, name); err != nil {
+			return fmt.Errorf("%s: %w", ns.name, err)
+		}
+	}
+	return s.staging.DeleteTable(ctx, stagedUploadName(name))
+}
+
+// publishTable swaps in the staged table of a Stager, other storages are written
+func publishTable(ctx context.Context, storage Storage, table *Table) error {
+	if stager, ok := storage.(Stager); ok {
+		return stager.PublishStaged(ctx, table)
+	}
+	return storage.WriteTable(ctx, table)
+}
+
+// stageTable wraps the table uploaded by dingtalkID into the table kept in the staging storage
+func stageTable(table *Table, dingtalkID string) (*Table, error) {
+	head, err := json.Marshal(table.Head)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal([]stagedUpload{{DingtalkID: dingtalkID, Head: string(head), Content: table.Content}})
+	if err != nil {
+		return nil, err
+	}
+	return &Table{Name: stagedUploadName(table.Name), Head: stagedHead, Content: string(content)}, nil
+}
+
+// unstageTable unwraps the uploaded table of name and its uploader from the staging storage
+func unstageTable(name string, staged *Table) (table *Table, dingtalkID string, err error) {
+	var rows []stagedUpload
+	if err = json.Unmarshal([]byte(staged.Content), &rows); err != nil {
+		return
+	}
+	if len(rows) != 1 {
+		err = fmt.Errorf("staged %s has %d rows", name, len(rows))
+		return
+	}
+	table = &Table{Name: name, Head: &pb.TableHead{}, Content: rows[0].Content}
+	if err = json.Unmarshal([]byte(rows[0].Head), table.Head); err != nil {
+		return
+	}
+	return table, rows[0].DingtalkID, nil
+}
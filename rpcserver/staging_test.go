@@ -0,0 +1,206 @@
+package rpcserver
+
+import (
+	"context"
+	"errors"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStaging(t *testing.T) {
+	dir, err := ioutil.TempDir("", "e2cdatabus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	notifier := &recordNotifier{}
+	s := NewService()
+	if err = s.SetSqliteConnect(filepath.Join(dir, "config.db")); err != nil {
+		t.Fatal(err)
+	}
+	s.AddStorage("memory", NewMemoryStorage())
+	s.SetNotifier(notifier)
+	// staged uploads are kept with their uploader by any storage
+	s.SetStagingStorage(testStorages(t)["file"])
+	sqlite := s.storages[0].storage.(*SqliteStorage)
+	stagedCount := func() (count int) {
+		if err := sqlite.db.Get(&count, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?",
+			stagedTableName(testTable.Name)); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	ctx := context.TODO()
+	if _, err = s.UpdateConfig(ctx, testUpdateReq); err != nil {
+		t.Fatal(err)
+	}
+	req := *testUpdateReq
+	req.Content = `[{"name":"名称1","sid":1,"type":2}]`
+	req.Stage = true
+	resp, err := s.UpdateConfig(ctx, &req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != StatusOK || resp.Version != 0 || len(resp.Sinks) != 1 || resp.Sinks[0].Name != "sqlite" {
+		t.Errorf("stage resp: %v", resp)
+	}
+	for _, ns := range s.storages {
+		if table, _ := ns.storage.ReadTable(ctx, testTable.Name); table.Content != testTable.Content {
+			t.Errorf("%s is written by staging: %v", ns.name, table.Content)
+		}
+	}
+	if names, _ := sqlite.ListTables(ctx); len(names) != 1 || stagedCount() != 1 {
+		t.Errorf("tables: %v, staged: %d", names, stagedCount())
+	}
+	if len(notifier.notifications) != 1 {
+		t.Errorf("staging is notified: %v", notifier.notifications)
+	}
+
+	publishReq := &pb.PublishConfigReq{Name: testTable.Name, DingtalkID: "lead"}
+	publishResp, err := s.PublishConfig(ctx, publishReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if publishResp.Status != StatusOK || publishResp.Version != 2 || len(publishResp.Sinks) != 2 {
+		t.Errorf("publish resp: %v", publishResp)
+	}
+	for _, ns := range s.storages {
+		if table, _ := ns.storage.ReadTable(ctx, testTable.Name); table == nil || table.Content != req.Content {
+			t.Errorf("%s is not published: %v", ns.name, table)
+		}
+	}
+	if n := notifier.notifications[len(notifier.notifications)-1]; n.Version != 2 || n.DingtalkID != "fandy" || n.ApprovedBy != "lead" || n.RowCount != 1 {
+		t.Errorf("notification: %+v", n)
+	}
+	if v, err := s.history.GetVersion(ctx, testTable.Name, 2); err != nil || v.DingtalkID != "fandy" || v.ApprovedBy != "lead" || v.Content != req.Content {
+		t.Errorf("published version: %+v, %v", v, err)
+	}
+	if stagedCount() != 0 {
+		t.Error("staged table is left after publish")
+	}
+	publishResp, err = s.PublishConfig(ctx, publishReq)
+	if err != nil || publishResp.Status != StatusFailed || publishResp.ErrMsg != "nothing staged for item_list" {
+		t.Errorf("publish again: %v, %v", publishResp, err)
+	}
+
+	if _, err = s.UpdateConfig(ctx, &req); err != nil {
+		t.Fatal(err)
+	}
+	discardResp, err := s.DiscardStaged(ctx, &pb.DiscardStagedReq{Name: testTable.Name})
+	if err != nil || discardResp.Status != StatusOK {
+		t.Fatalf("discard: %v, %v", discardResp, err)
+	}
+	if stagedCount() != 0 {
+		t.Error("staged table is left after discard")
+	}
+	if publishResp, err = s.PublishConfig(ctx, publishReq); err != nil || publishResp.Status != StatusFailed {
+		t.Errorf("publish discarded: %v, %v", publishResp, err)
+	}
+	if table, _ := sqlite.ReadTable(ctx, testTable.Name); table.Content != req.Content {
+		t.Errorf("discard changes the table: %v", table.Content)
+	}
+}
+
+func TestPublishStagedTwice(t *testing.T) {
+	ctx := context.TODO()
+	stager := testStorages(t)["sqlite"].(Stager)
+	storage := stager.(Storage)
+	if err := storage.WriteTable(ctx, testTable); err != nil {
+		t.Fatal(err)
+	}
+	staged := *testTable
+	staged.Content = `[{"name":"名称1","sid":1,"type":2}]`
+	if err := stager.StageTable(ctx, &staged); err != nil {
+		t.Fatal(err)
+	}
+	if err := stager.PublishStaged(ctx, &staged); err != nil {
+		t.Fatal(err)
+	}
+	// the live table must survive publishing without a staged table
+	if err := stager.PublishStaged(ctx, &staged); !errors.Is(err, ErrNothingStaged) {
+		t.Errorf("publish again, err: %v", err)
+	}
+	if table, err := storage.ReadTable(ctx, testTable.Name); err != nil || table.Content != staged.Content {
+		t.Errorf("published table is lost: %v, %v", table, err)
+	}
+}
+
+func TestStagingOnStorageBackend(t *testing.T) {
+	dir, err := ioutil.TempDir("", "e2cdatabus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := NewService()
+	if err = s.SetSqliteConnect(filepath.Join(dir, "config.db")); err != nil {
+		t.Fatal(err)
+	}
+	// staged uploads are kept in the same sqlite as the live tables
+	sqlite := s.storages[0].storage.(*SqliteStorage)
+	s.SetStagingStorage(NewSqliteStorage(sqlite.db))
+	ctx := context.TODO()
+	if _, err = s.UpdateConfig(ctx, testUpdateReq); err != nil {
+		t.Fatal(err)
+	}
+	req := *testUpdateReq
+	req.Content = `[{"name":"名称1","sid":1,"type":2}]`
+	req.Stage = true
+	if resp, err := s.UpdateConfig(ctx, &req); err != nil || resp.Status != StatusOK {
+		t.Fatalf("stage: %v, %v", resp, err)
+	}
+	if resp, err := s.GetConfig(ctx, &pb.GetConfigReq{Name: testTable.Name}); err != nil || resp.Content != testTable.Content {
+		t.Errorf("staging overwrites the live table: %v, %v", resp, err)
+	}
+	if names, _ := sqlite.ListTables(ctx); len(names) != 1 || names[0] != testTable.Name {
+		t.Errorf("staged upload is listed: %v", names)
+	}
+	// uploads can't overwrite the staged upload
+	upload := *testUpdateReq
+	upload.Name = stagedUploadName(testTable.Name)
+	if _, err = s.UpdateConfig(ctx, &upload); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("upload of a staged upload name, err: %v", err)
+	}
+	if resp, err := s.PublishConfig(ctx, &pb.PublishConfigReq{Name: testTable.Name, DingtalkID: "lead"}); err != nil || resp.Status != StatusOK {
+		t.Fatalf("publish: %v, %v", resp, err)
+	}
+	if resp, _ := s.GetConfig(ctx, &pb.GetConfigReq{Name: testTable.Name}); resp.Content != req.Content {
+		t.Errorf("published content: %s", resp.Content)
+	}
+}
+
+func TestDiscardStagedAfterRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "e2cdatabus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ctx := context.TODO()
+	req := *testUpdateReq
+	req.Stage = true
+	s := NewService()
+	if err = s.SetSqliteConnect(filepath.Join(dir, "config.db")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.UpdateConfig(ctx, &req); err != nil {
+		t.Fatal(err)
+	}
+
+	// the in memory staging storage of the restarted server doesn't keep the upload
+	restarted := NewService()
+	if err = restarted.SetSqliteConnect(filepath.Join(dir, "config.db")); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := restarted.DiscardStaged(ctx, &pb.DiscardStagedReq{Name: testTable.Name})
+	if err != nil || resp.Status != StatusFailed {
+		t.Errorf("discard after restart: %v, %v", resp, err)
+	}
+	sqlite := restarted.storages[0].storage.(*SqliteStorage)
+	var count int
+	if err = sqlite.db.Get(&count, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?",
+		stagedTableName(testTable.Name)); err != nil || count != 0 {
+		t.Errorf("staged table is left after restart: %d, %v", count, err)
+	}
+}
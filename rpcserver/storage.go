@@ -0,0 +1,180 @@
+package rpcserver
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"hash/crc32"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrTableNotFound is returned by a Storage when the requested table has never been written
+var ErrTableNotFound = errors.New("table not found")
+
+// ErrUndecodable is returned by a Storage when a stored table can't be decoded,
+// e.g. it's written by an older version or by other means
+var ErrUndecodable = errors.New("table can't be decoded")
+
+// ErrNothingStaged is returned by Stager.PublishStaged when the table is not staged,
+// e.g. it's published or discarded already
+var ErrNothingStaged = errors.New("nothing staged")
+
+// Table is a config table uploaded by excel2config
+type Table struct {
+	Name string
+	Head *pb.TableHead
+	// Content is a json array of rows, e.g. `[{"sid":1,"name":"foo"}]`
+	Content string
+}
+
+// Storage is a backend which config tables are written to and read from.
+// Implement it and pass it to Service.SetStorage to use your own backend.
+type Storage interface {
+	// WriteTable replaces the whole table with the given one
+	WriteTable(ctx context.Context, table *Table) error
+	// ReadTable returns the stored table, or ErrTableNotFound
+	ReadTable(ctx context.Context, name string) (*Table, error)
+	// ListTables returns names of all stored tables
+	ListTables(ctx context.Context) ([]string, error)
+	// DeleteTable removes the table, it's not an error if the table doesn't exist
+	DeleteTable(ctx context.Context, name string) error
+}
+
+// DryRunner is implemented by storages which can check a write without applying it,
+// the sql storages run the DDL and inserts in a transaction which is rolled back,
+// or into a scratch table which is dropped
+type DryRunner interface {
+	DryRunTable(ctx context.Context, table *Table) error
+}
+
+// Stager is implemented by storages which keep a staged table apart from the published one,
+// so publishing is the same table swap as WriteTable. Storages which aren't Stagers
+// are written when the table is published.
+type Stager interface {
+	// StageTable writes the table into its staging slot, replacing the table staged before
+	StageTable(ctx context.Context, table *Table) error
+	// PublishStaged swaps the staged table in, table is the one staged.
+	// It returns ErrNothingStaged and leaves the table as it is if nothing is staged.
+	PublishStaged(ctx context.Context, table *Table) error
+	// DiscardStaged drops the staged table, it's not an error if nothing is staged
+	DiscardStaged(ctx context.Context, name string) error
+}
+
+const (
+	// stagedTablePrefix is prepended to the tables staged by the sql storages,
+	// the meta prefix keeps them out of ListTables
+	stagedTablePrefix = metaTablePrefix + "staged_"
+	// scratchTablePrefix is prepended to the scratch tables of dry runs in mysql
+	scratchTablePrefix = metaTablePrefix + "dryrun_"
+	// stagedUploadPrefix is prepended to the tables of staged uploads in the staging storage,
+	// so they don't overwrite the tables of a storage on the same backend
+	stagedUploadPrefix = metaTablePrefix + "upload_"
+)
+
+// stagedTableName is the table which tableName is staged in by the sql storages
+func stagedTableName(tableName string) string {
+	return fitIdentifier(stagedTablePrefix + tableName)
+}
+
+// stagedUploadName is the table which the upload of tableName is kept in by the staging storage
+func stagedUploadName(tableName string) string {
+	return fitIdentifier(stagedUploadPrefix + tableName)
+}
+
+// tempName returns a unique name to build tableName in before it's swapped in,
+// the suffix is the time in nanoseconds in base 36 so writes within a second don't collide
+func tempName(tableName string) string {
+	return fitIdentifier(tableName + "_" + strconv.FormatInt(time.Now().UnixNano(), 36))
+}
+
+// fitIdentifier shortens a name derived from a table name to the max length of identifiers,
+// the end is replaced by a hash of the whole name, so the names derived from long table names
+// don't collide and keep their prefix, e.g. e2cdatabus_staged_
+func fitIdentifier(name string) string {
+	if len(name) <= maxIdentifierLength {
+		return name
+	}
+	hash := strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(name))), 36)
+	return name[:maxIdentifierLength-1-len(hash)] + "_" + hash
+}
+
+// decodeContent parses the json content of a table, numbers are kept as json.Number
+func decodeContent(content string) (rows []map[string]interface{}, err error) {
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.UseNumber()
+	rows = make([]map[string]interface{}, 0)
+	err = decoder.Decode(&rows)
+	return
+}
+
+// cellValue converts a decoded cell into a bind parameter,
+// missing cells are inserted as empty string like the mysql storage does
+func cellValue(cell interface{}) interface{} {
+	switch val := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	default:
+		bytes, _ := json.Marshal(val)
+		return string(bytes)
+	}
+}
+
+// scanRows reads all rows into maps of column name to value
+func scanRows(rows *sql.Rows) (res []map[string]interface{}, err error) {
+	res = make([]map[string]interface{}, 0)
+	cols, err := rows.Columns()
+	if err != nil {
+		return
+	}
+	for rows.Next() {
+		var row = make([]interface{}, len(cols))
+		var rowp = make([]interface{}, len(cols))
+		for i := range row {
+			rowp[i] = &row[i]
+		}
+		err = rows.Scan(rowp...)
+		if err != nil {
+			return
+		}
+		data := make(map[string]interface{})
+		for i, columnName := range cols {
+			if bytes, ok := row[i].([]byte); ok {
+				data[columnName] = string(bytes)
+			} else {
+				data[columnName] = row[i]
+			}
+		}
+		res = append(res, data)
+	}
+	err = rows.Err()
+	return
+}
+
+// typeRows converts the scanned values of each column to the type in the head
+func typeRows(rows []map[string]interface{}, head *pb.TableHead, decode func(ColumnType, interface{}) (interface{}, error)) error {
+	types, err := parseHeadTypes(head)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUndecodable, err)
+	}
+	for _, row := range rows {
+		for index, field := range head.Fields {
+			raw, ok := row[field]
+			if !ok {
+				continue
+			}
+			if row[field], err = decode(types[index], raw); err != nil {
+				return fmt.Errorf("%w: field %s: %v", ErrUndecodable, field, err)
+			}
+		}
+	}
+	return nil
+}
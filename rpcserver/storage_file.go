@@ -0,0 +1,157 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	fileContentExt = ".json"
+	fileSchemaExt  = ".schema.json"
+)
+
+// FileStorage writes each config table into dir as <name>.json, with the table head
+// in a sidecar <name>.schema.json. Files are written to a temp file and renamed,
+// so readers never see a half written file.
+type FileStorage struct {
+	dir string
+}
+
+// NewFileStorage return a Storage backed by files in dir
+func NewFileStorage(dir string) *FileStorage {
+	return &FileStorage{dir: dir}
+}
+
+func (f *FileStorage) WriteTable(ctx context.Context, table *Table) (err error) {
+	err = f.SaveSchema(ctx, table.Name, table.Head)
+	if err == nil {
+		err = f.writeFile(table.Name+fileContentExt, []byte(table.Content))
+	}
+	return
+}
+
+func (f *FileStorage) ReadTable(ctx context.Context, name string) (table *Table, err error) {
+	if err = f.checkName(name); err != nil {
+		return
+	}
+	content, err := ioutil.ReadFile(filepath.Join(f.dir, name+fileContentExt))
+	if os.IsNotExist(err) {
+		err = ErrTableNotFound
+	}
+	if err != nil {
+		return
+	}
+	table = &Table{Name: name, Content: string(content)}
+	schema, err := ioutil.ReadFile(filepath.Join(f.dir, name+fileSchemaExt))
+	if os.IsNotExist(err) {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	table.Head = &pb.TableHead{}
+	if err = json.Unmarshal(schema, table.Head); err != nil {
+		err = fmt.Errorf("%w: %v", ErrUndecodable, err)
+	}
+	return
+}
+
+func (f *FileStorage) ListTables(ctx context.Context) (names []string, err error) {
+	files, err := ioutil.ReadDir(f.dir)
+	if err != nil {
+		return
+	}
+	names = make([]string, 0, len(files))
+	for _, file := range files {
+		fileName := file.Name()
+		if file.IsDir() || strings.HasSuffix(fileName, fileSchemaExt) || !strings.HasSuffix(fileName, fileContentExt) {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(fileName, fileContentExt))
+	}
+	names = userTables(names)
+	sort.Strings(names)
+	return
+}
+
+func (f *FileStorage) DeleteTable(ctx context.Context, name string) (err error) {
+	if err = f.checkName(name); err != nil {
+		return
+	}
+	for _, fileName := range []string{name + fileContentExt, name + fileSchemaExt} {
+		err = os.Remove(filepath.Join(f.dir, fileName))
+		if err != nil && !os.IsNotExist(err) {
+			return
+		}
+	}
+	return nil
+}
+
+// SaveSchema writes the head into the sidecar, so a FileStorage is a SchemaStore too
+func (f *FileStorage) SaveSchema(ctx context.Context, name string, head *pb.TableHead) (err error) {
+	if err = f.checkName(name); err != nil {
+		return
+	}
+	schema, err := json.Marshal(head)
+	if err == nil {
+		err = f.writeFile(name+fileSchemaExt, schema)
+	}
+	return
+}
+
+func (f *FileStorage) LoadSchema(ctx context.Context, name string) (head *pb.TableHead, err error) {
+	if err = f.checkName(name); err != nil {
+		return
+	}
+	schema, err := ioutil.ReadFile(filepath.Join(f.dir, name+fileSchemaExt))
+	if os.IsNotExist(err) {
+		err = ErrTableNotFound
+	}
+	if err != nil {
+		return
+	}
+	head = &pb.TableHead{}
+	err = json.Unmarshal(schema, head)
+	return
+}
+
+// writeFile writes data to a temp file in the same dir and renames it to fileName
+func (f *FileStorage) writeFile(fileName string, data []byte) (err error) {
+	tmp, err := ioutil.TempFile(f.dir, "."+fileName+".tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(f.dir, fileName))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return
+}
+
+// checkName makes sure the table name can't escape the dir,
+// nor overwrite the schema sidecar of another table
+func (f *FileStorage) checkName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) ||
+		strings.HasSuffix(name+fileContentExt, fileSchemaExt) {
+		return errors.New("invalid table name for file storage: " + name)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package rpcserver
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryStorage keeps config tables in memory, they are lost when the process exits.
+// Service keeps staged uploads in one by default.
+type MemoryStorage struct {
+	mu     sync.RWMutex
+	tables map[string]Table
+}
+
+// NewMemoryStorage return a Storage backed by memory
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{tables: make(map[string]Table)}
+}
+
+func (m *MemoryStorage) WriteTable(ctx context.Context, table *Table) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tables[table.Name] = *table
+	return nil
+}
+
+func (m *MemoryStorage) ReadTable(ctx context.Context, name string) (*Table, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	table, ok := m.tables[name]
+	if !ok {
+		return nil, ErrTableNotFound
+	}
+	return &table, nil
+}
+
+func (m *MemoryStorage) ListTables(ctx context.Context) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.tables))
+	for name := range m.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (m *MemoryStorage) DeleteTable(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tables, name)
+	return nil
+}
@@ -0,0 +1,469 @@
+package rpcserver
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"github.com/jmoiron/sqlx"
+	"regexp"
+	"strings"
+)
+
+// mysqlMaxParams is the max number of placeholders in one mysql statement
+const mysqlMaxParams = 65535
+
+// mysqlColumnTypes are the mysql column types of tableHead types,
+// float is double precision like json numbers, so values round trip as uploaded
+var mysqlColumnTypes = map[string]string{
+	TypeInt:      "bigint(20)",
+	TypeString:   "text",
+	TypeFloat:    "double",
+	TypeDouble:   "double",
+	TypeBool:     "tinyint(1)",
+	TypeDatetime: "datetime",
+	TypeDate:     "date",
+	TypeJSON:     "json",
+}
+
+// mysqlKeyStringType is the type of string fields used by keys, mysql can't index text
+// without a prefix length and 191 characters of utf8mb4 fit in the 767 bytes index limit
+const mysqlKeyStringType = "varchar(191)"
+
+// mysqlHeadTypes are the tableHead types of mysql column types from show columns,
+// mysql 8 shows integer types without the display width
+var mysqlHeadTypes = map[string]string{
+	"bigint(20)":   TypeInt,
+	"bigint":       TypeInt,
+	"text":         TypeString,
+	"varchar(191)": TypeString,
+	"float":        TypeFloat,
+	"double":       TypeDouble,
+	"tinyint(1)":   TypeBool,
+	"datetime":     TypeDatetime,
+	"date":         TypeDate,
+	"json":         TypeJSON,
+}
+
+// MysqlStorage writes each config table into a mysql table named by the table mapping,
+// by default the table has the same name as the sheet
+type MysqlStorage struct {
+	db      *sqlx.DB
+	mapping TableMapping
+	schemas SchemaStore
+}
+
+// NewMysqlStorage return a Storage backed by mysql
+func NewMysqlStorage(db *sqlx.DB) *MysqlStorage {
+	return &MysqlStorage{db: db}
+}
+
+// SetTableMapping setup how sheet names are mapped to mysql tables
+func (m *MysqlStorage) SetTableMapping(mapping TableMapping) {
+	m.mapping = mapping
+}
+
+// SetSchemaStore setup where ReadTable gets the declared heads of tables, as the heads rebuilt
+// from mysql columns lose the keys, nullable columns, defaults and the element types of arrays.
+// Service sets its schema store on the mysql storages.
+func (m *MysqlStorage) SetSchemaStore(schemas SchemaStore) {
+	m.schemas = schemas
+}
+
+func (m *MysqlStorage) WriteTable(ctx context.Context, table *Table) (err error) {
+	tableName, err := m.physicalName(table.Name)
+	if err != nil {
+		return
+	}
+	err = checkTableLength(tableName, mysqlMaxIdentifierLength, table.Head)
+	if err != nil {
+		return
+	}
+	err = m.db.Ping()
+	if err != nil {
+		return
+	}
+	tempTableName := tempName(tableName)
+	err = m.exportTableToMysql(ctx, m.db, table, tempTableName)
+	if err == nil {
+		err = m.renameTable(ctx, m.db, tableName, tempTableName)
+	}
+	return
+}
+
+// DryRunTable creates and fills a scratch table like WriteTable, then drops it,
+// as DDL can't be rolled back in mysql. The meta prefix keeps the scratch table out of ListTables.
+func (m *MysqlStorage) DryRunTable(ctx context.Context, table *Table) (err error) {
+	tableName, err := m.physicalName(table.Name)
+	if err != nil {
+		return
+	}
+	err = checkTableLength(tableName, mysqlMaxIdentifierLength, table.Head)
+	if err != nil {
+		return
+	}
+	err = m.db.Ping()
+	if err != nil {
+		return
+	}
+	scratchTableName := tempName(scratchTablePrefix + tableName)
+	err = m.exportTableToMysql(ctx, m.db, table, scratchTableName)
+	if dropErr := m.dropTable(m.db, scratchTableName); err == nil {
+		err = dropErr
+	}
+	return
+}
+
+// StageTable builds the table into a temp table like WriteTable, then swaps it in as the staged table
+func (m *MysqlStorage) StageTable(ctx context.Context, table *Table) (err error) {
+	tableName, err := m.physicalName(table.Name)
+	if err != nil {
+		return
+	}
+	err = checkTableLength(tableName, mysqlMaxIdentifierLength, table.Head)
+	if err != nil {
+		return
+	}
+	err = m.db.Ping()
+	if err != nil {
+		return
+	}
+	stagedName := stagedTableName(tableName)
+	tempTableName := tempName(stagedName)
+	err = m.exportTableToMysql(ctx, m.db, table, tempTableName)
+	if err == nil {
+		err = m.renameTable(ctx, m.db, stagedName, tempTableName)
+	}
+	return
+}
+
+func (m *MysqlStorage) PublishStaged(ctx context.Context, table *Table) (err error) {
+	tableName, err := m.physicalName(table.Name)
+	if err != nil {
+		return
+	}
+	err = m.db.Ping()
+	if err != nil {
+		return
+	}
+	// renameTable moves the live table away first, so it must not run without a staged table
+	stagedName := stagedTableName(tableName)
+	exists, err := m.tableExists(m.db, stagedName)
+	if err != nil {
+		return
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrNothingStaged, table.Name)
+	}
+	return m.renameTable(ctx, m.db, tableName, stagedName)
+}
+
+func (m *MysqlStorage) DiscardStaged(ctx context.Context, name string) error {
+	tableName, err := m.physicalName(name)
+	if err != nil {
+		return err
+	}
+	return m.dropTable(m.db, stagedTableName(tableName))
+}
+
+func (m *MysqlStorage) ReadTable(ctx context.Context, name string) (table *Table, err error) {
+	tableName, err := m.physicalName(name)
+	if err != nil {
+		return
+	}
+	err = m.db.Ping()
+	if err != nil {
+		return
+	}
+	rows, err := m.db.QueryContext(ctx, "select * from "+m.quoteName(tableName))
+	reg, _ := regexp.Compile(`Table.*?doesn't exist`)
+	if err != nil && reg.Match([]byte(err.Error())) {
+		err = ErrTableNotFound
+		return
+	}
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	res, err := scanRows(rows)
+	if err != nil {
+		return
+	}
+	columns, err := m.readHead(tableName)
+	if err != nil {
+		return
+	}
+	head, err := schemaHead(ctx, m.schemas, name, columns, m.declaredHeadType)
+	if err != nil {
+		return
+	}
+	err = typeRows(res, head, decodeCell)
+	if err != nil {
+		return
+	}
+	bytes, _ := json.Marshal(res)
+	table = &Table{Name: name, Head: head, Content: string(bytes)}
+	return
+}
+
+func (m *MysqlStorage) ListTables(ctx context.Context) (names []string, err error) {
+	var tableNames []string
+	err = m.db.Select(&tableNames, "show tables"+m.fromDatabase())
+	if err != nil {
+		return
+	}
+	for _, tableName := range userTables(tableNames) {
+		if name, ok := m.mapping.sheetName(tableName); ok {
+			names = append(names, name)
+		}
+	}
+	// staged uploads in a mapped staging storage are mapped like sheets
+	names = userTables(names)
+	return
+}
+
+func (m *MysqlStorage) DeleteTable(ctx context.Context, name string) error {
+	tableName, err := m.physicalName(name)
+	if err != nil {
+		return err
+	}
+	return m.dropTable(m.db, tableName)
+}
+
+// physicalName maps the sheet name to a table name, and makes sure both the
+// table name and the database are identifiers, mysql allows 64 characters in them
+func (m *MysqlStorage) physicalName(name string) (tableName string, err error) {
+	tableName, err = m.mapping.tableName(name)
+	if err == nil {
+		err = checkIdentifierLength(tableName, mysqlMaxIdentifierLength)
+	}
+	if err == nil && m.mapping.Database != "" {
+		err = checkIdentifierLength(m.mapping.Database, mysqlMaxIdentifierLength)
+	}
+	return
+}
+
+// quoteName returns the quoted table name qualified with the database of the mapping
+func (m *MysqlStorage) quoteName(tableName string) string {
+	if m.mapping.Database == "" {
+		return mysqlQuoteIdentifier(tableName)
+	}
+	return mysqlQuoteIdentifier(m.mapping.Database) + "." + mysqlQuoteIdentifier(tableName)
+}
+
+func (m *MysqlStorage) fromDatabase() string {
+	if m.mapping.Database == "" {
+		return ""
+	}
+	return " from " + mysqlQuoteIdentifier(m.mapping.Database)
+}
+
+func (m *MysqlStorage) tableExists(db *sqlx.DB, tableName string) (exists bool, err error) {
+	query := "select count(*) from information_schema.tables where table_schema = database() and table_name = ?"
+	args := []interface{}{tableName}
+	if m.mapping.Database != "" {
+		query = "select count(*) from information_schema.tables where table_schema = ? and table_name = ?"
+		args = []interface{}{m.mapping.Database, tableName}
+	}
+	var count int
+	err = db.Get(&count, query, args...)
+	exists = count > 0
+	return
+}
+
+// readHead rebuilds the table head from column definitions and comments
+func (m *MysqlStorage) readHead(name string) (head *pb.TableHead, err error) {
+	var columns []struct {
+		Field   string `db:"Field"`
+		Type    string `db:"Type"`
+		Comment string `db:"Comment"`
+	}
+	err = m.db.Unsafe().Select(&columns, "show full columns from "+m.quoteName(name))
+	if err != nil {
+		return
+	}
+	head = &pb.TableHead{}
+	for _, column := range columns {
+		head.Fields = append(head.Fields, column.Field)
+		head.Types = append(head.Types, m.headType(column.Type))
+		head.Descs = append(head.Descs, column.Comment)
+	}
+	return
+}
+
+func (m *MysqlStorage) exportTableToMysql(ctx context.Context, db *sqlx.DB, table *Table, tableName string) (err error) {
+	types, err := parseHeadTypes(table.Head)
+	if err != nil {
+		return
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return
+	}
+	err = m.dropTable(db, tableName)
+	if err == nil {
+		err = m.createTable(tx, table, types, tableName)
+	}
+	if err == nil {
+		err = m.insertToTable(tx, table, types, tableName)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+	return
+}
+
+func (m *MysqlStorage) renameTable(ctx context.Context, db *sqlx.DB, tableName, tmpTableName string) (err error) {
+	bakTableName := fitIdentifier(tableName + "_bak")
+	exists, err := m.tableExists(db, tableName)
+	if err != nil {
+		return
+	}
+	if exists {
+		_, err = db.Exec("alter table " + m.quoteName(tableName) + " rename to " + m.quoteName(bakTableName))
+	}
+	if err == nil {
+		_, err = db.Exec("alter table " + m.quoteName(tmpTableName) + " rename to " + m.quoteName(tableName))
+	}
+	if err == nil {
+		err = m.dropTable(db, bakTableName)
+	}
+	return
+}
+
+func (m *MysqlStorage) createTable(tx *sql.Tx, table *Table, types []ColumnType, tableName string) (err error) {
+	keys, err := parseTableKeys(table.Head)
+	if err != nil {
+		return
+	}
+	keyFields := keys.keyFields()
+	definitions := make([]string, 0, len(table.Head.Fields)+1+len(keys.Uniques)+len(keys.Indexes))
+	for index, row := range table.Head.Fields {
+		ct := types[index]
+		fieldTy := m.columnType(ct)
+		if keyFields[row] && ct.Base == TypeString && !ct.Array {
+			fieldTy = mysqlKeyStringType
+		}
+		if fieldTy == mysqlColumnTypes[TypeString] {
+			// text columns can't have a literal default, missing cells are filled on insert anyway
+			ct.Default = nil
+		}
+		definitions = append(definitions, mysqlQuoteIdentifier(row)+" "+fieldTy+columnConstraints(ct, mysqlQuoteLiteral)+
+			" COMMENT "+mysqlQuoteLiteral(table.Head.Descs[index]))
+	}
+	if len(keys.PrimaryKey) > 0 {
+		definitions = append(definitions, "PRIMARY KEY ("+m.quoteFields(keys.PrimaryKey)+")")
+	}
+	for _, unique := range keys.Uniques {
+		definitions = append(definitions, "UNIQUE KEY "+mysqlQuoteIdentifier(unique.Name)+" ("+m.quoteFields(unique.Fields)+")")
+	}
+	for _, index := range keys.Indexes {
+		definitions = append(definitions, "KEY "+mysqlQuoteIdentifier(index.Name)+" ("+m.quoteFields(index.Fields)+")")
+	}
+	createSql := "CREATE TABLE " + m.quoteName(tableName) + " (" + strings.Join(definitions, ",") + ") DEFAULT CHARSET=utf8mb4"
+	_, err = tx.Exec(createSql)
+	return
+}
+
+func (m *MysqlStorage) quoteFields(fields []string) string {
+	quoted := make([]string, 0, len(fields))
+	for _, field := range fields {
+		quoted = append(quoted, mysqlQuoteIdentifier(field))
+	}
+	return strings.Join(quoted, ",")
+}
+
+func (m *MysqlStorage) insertToTable(tx *sql.Tx, table *Table, types []ColumnType, tableName string) (err error) {
+	content, err := decodeContent(table.Content)
+	if err != nil || len(content) == 0 {
+		return
+	}
+	fields := make([]string, 0, len(table.Head.Fields))
+	placeholders := make([]string, 0, len(table.Head.Fields))
+	for _, field := range table.Head.Fields {
+		fields = append(fields, mysqlQuoteIdentifier(field))
+		placeholders = append(placeholders, "?")
+	}
+	insertSql := "INSERT INTO " + m.quoteName(tableName) + " (" + strings.Join(fields, ",") + ") VALUES "
+	rowPlaceholders := "(" + strings.Join(placeholders, ",") + ")"
+	batchSize := mysqlMaxParams / len(fields)
+	for start := 0; start < len(content); start += batchSize {
+		end := start + batchSize
+		if end > len(content) {
+			end = len(content)
+		}
+		values := make([]string, 0, end-start)
+		args := make([]interface{}, 0, (end-start)*len(fields))
+		for _, row := range content[start:end] {
+			for index, field := range table.Head.Fields {
+				args = append(args, encodeCell(types[index], row[field]))
+			}
+			values = append(values, rowPlaceholders)
+		}
+		_, err = tx.Exec(insertSql+strings.Join(values, ","), args...)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// columnType returns the mysql column type, arrays are stored as json
+func (m *MysqlStorage) columnType(ct ColumnType) string {
+	if ct.Array {
+		return "json"
+	}
+	if ct.Base == TypeEnum {
+		values := make([]string, 0, len(ct.EnumValues))
+		for _, value := range ct.EnumValues {
+			values = append(values, mysqlQuoteLiteral(value))
+		}
+		return "enum(" + strings.Join(values, ",") + ")"
+	}
+	return mysqlColumnTypes[ct.Base]
+}
+
+// declaredHeadType returns the tableHead type of the column declared for a type, like readHead
+func (m *MysqlStorage) declaredHeadType(ct ColumnType) string {
+	return m.headType(m.columnType(ct))
+}
+
+// headType returns the tableHead type of a mysql column type from show columns
+func (m *MysqlStorage) headType(columnType string) string {
+	if strings.HasPrefix(columnType, "enum(") {
+		values := strings.Split(strings.TrimSuffix(strings.TrimPrefix(columnType, "enum("), ")"), ",")
+		for i, value := range values {
+			values[i] = strings.Replace(strings.Trim(value, "'"), "''", "'", -1)
+		}
+		return TypeEnum + "(" + strings.Join(values, ",") + ")"
+	}
+	if ty, ok := mysqlHeadTypes[columnType]; ok {
+		return ty
+	}
+	return TypeString
+}
+
+func (m *MysqlStorage) dropTable(db *sqlx.DB, tableName string) (err error) {
+	dropSql := "drop table if exists " + m.quoteName(tableName)
+	_, err = db.Exec(dropSql)
+	if err != nil {
+		return err
+	}
+	return
+}
+
+func mysqlQuoteIdentifier(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+// mysqlQuoteLiteral quotes a string for statements which don't take placeholders, like COMMENT of DDL
+func mysqlQuoteLiteral(literal string) string {
+	literal = strings.Replace(literal, `\`, `\\`, -1)
+	literal = strings.Replace(literal, "'", "''", -1)
+	return "'" + literal + "'"
+}
@@ -0,0 +1,203 @@
+package rpcserver
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"strconv"
+	"strings"
+)
+
+const (
+	// pgUndefinedTable is the postgres error code of a missing table
+	pgUndefinedTable = "42P01"
+	// pgMaxParams is the max number of bind parameters in one postgres statement
+	pgMaxParams = 65535
+)
+
+// pgColumnTypes are the postgres column types of tableHead types, float is double precision
+// like json numbers, so values round trip as uploaded,
+// enums are text columns with a check constraint and arrays are native arrays
+var pgColumnTypes = map[string]string{
+	TypeInt:      "bigint",
+	TypeString:   "text",
+	TypeFloat:    "double precision",
+	TypeDouble:   "double precision",
+	TypeBool:     "boolean",
+	TypeDatetime: "timestamp",
+	TypeDate:     "date",
+	TypeJSON:     "jsonb",
+	TypeEnum:     "text",
+}
+
+// pgHeadTypes are the tableHead types of postgres column types returned by format_type
+var pgHeadTypes = map[string]string{
+	"bigint":                      TypeInt,
+	"text":                        TypeString,
+	"real":                        TypeFloat,
+	"double precision":            TypeDouble,
+	"boolean":                     TypeBool,
+	"timestamp without time zone": TypeDatetime,
+	"date":                        TypeDate,
+	"jsonb":                       TypeJSON,
+}
+
+// PostgresStorage writes each config table into a postgres table of the same name
+// in the current schema. DDL is transactional in postgres, so the temp table is
+// created, filled and swapped in within a single transaction.
+type PostgresStorage struct {
+	*txSqlStorage
+}
+
+// NewPostgresStorage return a Storage backed by postgres
+func NewPostgresStorage(db *sqlx.DB) *PostgresStorage {
+	return &PostgresStorage{&txSqlStorage{db: db, dialect: postgresDialect{}}}
+}
+
+func (p *PostgresStorage) ReadTable(ctx context.Context, name string) (table *Table, err error) {
+	if err = checkIdentifier(name); err != nil {
+		return
+	}
+	rows, err := p.db.QueryContext(ctx, "SELECT * FROM "+pq.QuoteIdentifier(name))
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == pgUndefinedTable {
+		err = ErrTableNotFound
+		return
+	}
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	res, err := scanRows(rows)
+	if err != nil {
+		return
+	}
+	head, err := p.readHead(ctx, name)
+	if err != nil {
+		return
+	}
+	err = typeRows(res, head, p.decodeCell)
+	if err != nil {
+		return
+	}
+	bytes, _ := json.Marshal(res)
+	table = &Table{Name: name, Head: head, Content: string(bytes)}
+	return
+}
+
+func (p *PostgresStorage) ListTables(ctx context.Context) (names []string, err error) {
+	err = p.db.SelectContext(ctx, &names, "SELECT tablename FROM pg_tables WHERE schemaname = current_schema()")
+	names = userTables(names)
+	return
+}
+
+// readHead rebuilds the table head from column definitions and comments
+func (p *PostgresStorage) readHead(ctx context.Context, name string) (head *pb.TableHead, err error) {
+	var columns []struct {
+		Field   string `db:"field"`
+		Type    string `db:"type"`
+		Comment string `db:"comment"`
+	}
+	err = p.db.SelectContext(ctx, &columns, `SELECT a.attname AS field, format_type(a.atttypid, a.atttypmod) AS type,
+		coalesce(col_description(a.attrelid, a.attnum), '') AS comment
+		FROM pg_attribute a WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`, pq.QuoteIdentifier(name))
+	if err != nil {
+		return
+	}
+	head = &pb.TableHead{}
+	for _, column := range columns {
+		ty, ok := pgHeadTypes[strings.TrimSuffix(column.Type, arraySuffix)]
+		if !ok {
+			ty = TypeString
+		}
+		if strings.HasSuffix(column.Type, arraySuffix) {
+			ty += arraySuffix
+		}
+		head.Fields = append(head.Fields, column.Field)
+		head.Types = append(head.Types, ty)
+		head.Descs = append(head.Descs, column.Comment)
+	}
+	return
+}
+
+// postgresDialect declares enums as text with a check constraint and arrays as native arrays
+type postgresDialect struct{}
+
+func (postgresDialect) quoteIdentifier(name string) string {
+	return pq.QuoteIdentifier(name)
+}
+
+func (postgresDialect) quoteLiteral(literal string) string {
+	return pq.QuoteLiteral(literal)
+}
+
+func (postgresDialect) placeholder(index int) string {
+	return "$" + strconv.Itoa(index)
+}
+
+func (postgresDialect) maxParams() int {
+	return pgMaxParams
+}
+
+func (postgresDialect) commentSql(tableName, field, desc string) string {
+	return "COMMENT ON COLUMN " + pq.QuoteIdentifier(tableName) + "." + pq.QuoteIdentifier(field) + " IS " + pq.QuoteLiteral(desc)
+}
+
+func (postgresDialect) tableCountSql() string {
+	return "SELECT COUNT(*) FROM pg_tables WHERE schemaname = current_schema() AND tablename = $1"
+}
+
+func (postgresDialect) columnType(ct ColumnType, field string) string {
+	ty := pgColumnTypes[ct.Base]
+	if ct.Array {
+		return ty + arraySuffix
+	}
+	if ct.Base == TypeEnum {
+		values := make([]string, 0, len(ct.EnumValues))
+		for _, value := range ct.EnumValues {
+			values = append(values, pq.QuoteLiteral(value))
+		}
+		ty += " CHECK (" + pq.QuoteIdentifier(field) + " IN (" + strings.Join(values, ", ") + "))"
+	}
+	return ty
+}
+
+// encodeCell encodes arrays as postgres arrays, other cells like the other sql storages
+func (postgresDialect) encodeCell(ct ColumnType, cell interface{}) interface{} {
+	if cell == nil {
+		cell = ct.Default
+	}
+	elems, ok := cell.([]interface{})
+	if !ct.Array || !ok {
+		return encodeCell(ct, cell)
+	}
+	values := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		values = append(values, fmt.Sprint(cellValue(elem)))
+	}
+	return pq.Array(values)
+}
+
+// decodeCell decodes postgres arrays, other values like the other sql storages
+func (p *PostgresStorage) decodeCell(ct ColumnType, raw interface{}) (interface{}, error) {
+	if !ct.Array || raw == nil {
+		return decodeCell(ct, raw)
+	}
+	var dest interface{}
+	switch ct.Base {
+	case TypeInt:
+		dest = &pq.Int64Array{}
+	case TypeFloat, TypeDouble:
+		dest = &pq.Float64Array{}
+	case TypeBool:
+		dest = &pq.BoolArray{}
+	default:
+		dest = &pq.StringArray{}
+	}
+	err := dest.(sql.Scanner).Scan(raw)
+	return dest, err
+}
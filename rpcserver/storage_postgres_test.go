@@ -0,0 +1,129 @@
+package rpcserver
+
+import (
+	"context"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testPostgresDsn is the postgres to run the integration tests against, they are skipped if it's not set
+var testPostgresDsn = os.Getenv("E2CDATABUS_POSTGRES_DSN")
+
+var testPostgresTable = &Table{
+	Name: "item_list",
+	Head: &pb.TableHead{
+		Fields:     []string{"sid", "type", "kind", "tags"},
+		Types:      []string{"int", "float", "enum(a,b)", "int[]"},
+		Descs:      []string{"流水ID", "类型", "种类", "标签"},
+		PrimaryKey: []string{"sid", "type"},
+		Nullable:   []string{"tags"},
+	},
+	Content: `[{"kind":"a","sid":1,"tags":[1,2],"type":1.5},{"kind":"b","sid":2,"tags":null,"type":2}]`,
+}
+
+func TestPostgresSqls(t *testing.T) {
+	p := NewPostgresStorage(nil)
+	types, err := parseHeadTypes(testPostgresTable.Head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := parseTableKeys(testPostgresTable.Head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmts := p.createTableSqls(testPostgresTable, types, keys, "item_list_tmp")
+	expected := []string{
+		`DROP TABLE IF EXISTS "item_list_tmp"`,
+		`CREATE TABLE "item_list_tmp" ("sid" bigint NOT NULL, "type" double precision NOT NULL, ` +
+			`"kind" text CHECK ("kind" IN ('a', 'b')) NOT NULL, "tags" bigint[], PRIMARY KEY ("sid", "type"))`,
+		`COMMENT ON COLUMN "item_list_tmp"."sid" IS '流水ID'`,
+		`COMMENT ON COLUMN "item_list_tmp"."type" IS '类型'`,
+		`COMMENT ON COLUMN "item_list_tmp"."kind" IS '种类'`,
+		`COMMENT ON COLUMN "item_list_tmp"."tags" IS '标签'`,
+	}
+	if !reflect.DeepEqual(stmts, expected) {
+		t.Errorf("create table sqls:\n%s", strings.Join(stmts, "\n"))
+	}
+
+	stmts, args, err := p.insertSqls(testPostgresTable, types, "item_list_tmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts) != 1 || stmts[0] != `INSERT INTO "item_list_tmp" ("sid", "type", "kind", "tags") VALUES ($1, $2, $3, $4), ($5, $6, $7, $8)` {
+		t.Errorf("insert sqls: %v", stmts)
+	}
+	if len(args) != 1 || len(args[0]) != 8 || args[0][0] != "1" || args[0][7] != nil {
+		t.Errorf("insert args: %v", args)
+	}
+
+	// rows are inserted in batches within the limit of bind parameters
+	rows := make([]string, 0, pgMaxParams/4+1)
+	for i := 0; i < cap(rows); i++ {
+		rows = append(rows, fmt.Sprintf(`{"kind":"a","sid":%d,"type":1}`, i))
+	}
+	big := &Table{Name: "item_list", Head: testPostgresTable.Head, Content: "[" + strings.Join(rows, ",") + "]"}
+	stmts, args, err = p.insertSqls(big, types, "item_list_tmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts) != 2 || len(args[0]) != pgMaxParams/4*4 || len(args[1]) != 4 || !strings.HasSuffix(stmts[1], "VALUES ($1, $2, $3, $4)") {
+		t.Errorf("batches: %d, args: %d", len(stmts), len(args))
+	}
+}
+
+func TestPostgresStorage(t *testing.T) {
+	if testPostgresDsn == "" {
+		t.Skip("E2CDATABUS_POSTGRES_DSN is not set")
+	}
+	db, err := sqlx.Connect("postgres", testPostgresDsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.TODO()
+	storage := NewPostgresStorage(db)
+	defer storage.DeleteTable(ctx, testPostgresTable.Name)
+	// writes within a second, e.g. an upload followed by a rollback, must not collide
+	for i := 0; i < 3; i++ {
+		if err = storage.WriteTable(ctx, testPostgresTable); err != nil {
+			t.Fatal(err)
+		}
+	}
+	table, err := storage.ReadTable(ctx, testPostgresTable.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.Content != testPostgresTable.Content {
+		t.Errorf("content is different: %v", table.Content)
+	}
+	dup := *testPostgresTable
+	dup.Content = `[{"kind":"a","sid":1,"type":1.5},{"kind":"b","sid":1,"type":1.5}]`
+	if err = storage.WriteTable(ctx, &dup); err == nil {
+		t.Error("duplicate primary key is written")
+	}
+	if table, _ = storage.ReadTable(ctx, testPostgresTable.Name); table.Content != testPostgresTable.Content {
+		t.Errorf("failed write changes the table: %v", table.Content)
+	}
+
+	// a dry run doesn't wait for the readers of the live table, as it never drops it
+	reader, err := db.Beginx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Rollback()
+	if _, err = reader.Exec("LOCK TABLE " + pq.QuoteIdentifier(testPostgresTable.Name) + " IN ACCESS SHARE MODE"); err != nil {
+		t.Fatal(err)
+	}
+	timeout, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	if err = storage.DryRunTable(timeout, testPostgresTable); err != nil {
+		t.Errorf("dry run with a reader of the table: %v", err)
+	}
+}
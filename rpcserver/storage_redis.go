@@ -0,0 +1,306 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"github.com/go-redis/redis"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// redisTablesKey is a set of all table names written by e2cdatabus
+	redisTablesKey = "e2cdatabus:tables"
+	// redisMetaSuffix is appended to the table key for the meta hash of RedisLayoutHash
+	redisMetaSuffix = ":meta"
+)
+
+// RedisMode is how to connect to redis
+type RedisMode int
+
+const (
+	RedisModeStandalone RedisMode = iota
+	RedisModeSentinel
+	RedisModeCluster
+)
+
+// RedisConf is the connect config of the redis storage
+type RedisConf struct {
+	Mode RedisMode
+	// Addrs is the server addr in standalone mode, sentinel addrs in sentinel mode
+	// and seed node addrs in cluster mode, e.g. []string{"127.0.0.1:6379"}
+	Addrs    []string
+	Password string
+	// MasterName is the master name in sentinel mode
+	MasterName string
+	// DB is the database index, it's ignored in cluster mode
+	DB       int
+	PoolSize int
+
+	// Channel is where refresh notifications are published, default is "config_refresh"
+	Channel string
+	// KeyPrefix is prepended to every key, e.g. "config:"
+	KeyPrefix string
+	// TTL is the expiration of written tables, zero means no expiration
+	TTL    time.Duration
+	Layout RedisLayout
+}
+
+// NewRedisClient connects to redis in the mode of conf
+func NewRedisClient(conf RedisConf) (client redis.UniversalClient, err error) {
+	if len(conf.Addrs) == 0 {
+		return nil, errors.New("error redis addr")
+	}
+	if conf.PoolSize == 0 {
+		conf.PoolSize = 100
+	}
+	switch conf.Mode {
+	case RedisModeSentinel:
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    conf.MasterName,
+			SentinelAddrs: conf.Addrs,
+			Password:      conf.Password,
+			DB:            conf.DB,
+			PoolSize:      conf.PoolSize,
+		})
+	case RedisModeCluster:
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:    conf.Addrs,
+			Password: conf.Password,
+			PoolSize: conf.PoolSize,
+		})
+	default:
+		client = redis.NewClient(&redis.Options{
+			Addr:     conf.Addrs[0],
+			Password: conf.Password,
+			DB:       conf.DB,
+			PoolSize: conf.PoolSize,
+		})
+	}
+	return
+}
+
+// RedisLayout is how a config table is stored in redis
+type RedisLayout int
+
+const (
+	// RedisLayoutString stores the json content of a table under a key of the table name
+	RedisLayoutString RedisLayout = iota
+	// RedisLayoutHash stores a table in a hash of the table name, each row is a field keyed
+	// by the value of the primary key, e.g. HGET item_list 1001. Values of a composite key
+	// are joined by ":", e.g. HGET level_reward 3:1, so they must not contain ":". Rows of
+	// tables without a primary key are keyed by their index from 0.
+	// The table head and the order of rows are kept in the hash {<name>}:meta.
+	RedisLayoutHash
+)
+
+// RedisStorage writes config tables into redis in the given layout.
+// Keys written together share a hash tag, so it works with redis cluster too.
+type RedisStorage struct {
+	redis  redis.UniversalClient
+	layout RedisLayout
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisStorage return a Storage backed by redis
+func NewRedisStorage(client redis.UniversalClient) *RedisStorage {
+	return &RedisStorage{redis: client}
+}
+
+// SetLayout setup how tables are stored, default is RedisLayoutString
+func (r *RedisStorage) SetLayout(layout RedisLayout) {
+	r.layout = layout
+}
+
+// SetKeyPrefix setup the namespace of all keys, table "item_list" is stored under
+// key "config:item_list" with prefix "config:"
+func (r *RedisStorage) SetKeyPrefix(prefix string) {
+	r.prefix = prefix
+}
+
+// SetTTL setup the expiration of written tables, zero means no expiration
+func (r *RedisStorage) SetTTL(ttl time.Duration) {
+	r.ttl = ttl
+}
+
+func (r *RedisStorage) WriteTable(ctx context.Context, table *Table) (err error) {
+	_, err = r.redis.Ping().Result()
+	if err != nil {
+		return
+	}
+	if r.layout == RedisLayoutHash {
+		err = r.writeHash(table)
+	} else {
+		err = r.redis.Set(r.key(table.Name), table.Content, r.ttl).Err()
+	}
+	if err == nil {
+		err = r.redis.SAdd(r.key(redisTablesKey), table.Name).Err()
+	}
+	return
+}
+
+func (r *RedisStorage) ReadTable(ctx context.Context, name string) (table *Table, err error) {
+	_, err = r.redis.Ping().Result()
+	if err != nil {
+		return
+	}
+	if r.layout == RedisLayoutHash {
+		return r.readHash(name)
+	}
+	content, err := r.redis.Get(r.key(name)).Result()
+	if err == redis.Nil {
+		err = ErrTableNotFound
+	}
+	if err != nil {
+		return
+	}
+	table = &Table{Name: name, Content: content}
+	return
+}
+
+// ListTables returns the tables in the set of table names whose keys still exist,
+// the set doesn't expire with the tables so the names of expired ones are removed from it
+func (r *RedisStorage) ListTables(ctx context.Context) (names []string, err error) {
+	members, err := r.redis.SMembers(r.key(redisTablesKey)).Result()
+	if err != nil {
+		return
+	}
+	names = make([]string, 0, len(members))
+	for _, name := range userTables(members) {
+		key := r.key(name)
+		if r.layout == RedisLayoutHash {
+			key = r.metaKey(name)
+		}
+		var exists int64
+		exists, err = r.redis.Exists(key).Result()
+		if err != nil {
+			return
+		}
+		if exists > 0 {
+			names = append(names, name)
+		} else if err = r.redis.SRem(r.key(redisTablesKey), name).Err(); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (r *RedisStorage) DeleteTable(ctx context.Context, name string) (err error) {
+	err = r.redis.Del(r.key(name), r.metaKey(name)).Err()
+	if err == nil {
+		err = r.redis.SRem(r.key(redisTablesKey), name).Err()
+	}
+	return
+}
+
+// key returns the redis key of a table
+func (r *RedisStorage) key(name string) string {
+	return r.prefix + name
+}
+
+// metaKey returns the key of the meta hash, "{key}" hashes to the same cluster slot as "key"
+func (r *RedisStorage) metaKey(name string) string {
+	return "{" + r.key(name) + "}" + redisMetaSuffix
+}
+
+// writeHash fills temp keys first and renames them in a MULTI,
+// so readers see either the old table or the new one.
+// All the keys share the hash tag of the table key to stay in one cluster slot.
+func (r *RedisStorage) writeHash(table *Table) (err error) {
+	content, err := decodeContent(table.Content)
+	if err != nil {
+		return
+	}
+	head, err := json.Marshal(table.Head)
+	if err != nil {
+		return
+	}
+	headKeys, err := parseTableKeys(table.Head)
+	if err != nil {
+		return
+	}
+	rows := make(map[string]interface{}, len(content))
+	keys := make([]string, 0, len(content))
+	for index, row := range content {
+		key := strconv.Itoa(index)
+		if len(headKeys.PrimaryKey) > 0 {
+			key = rowKey(row, headKeys.PrimaryKey)
+		}
+		// values of a composite key are joined by ":", so they can't contain it
+		if len(headKeys.PrimaryKey) > 1 && strings.Count(key, ":") != len(headKeys.PrimaryKey)-1 {
+			return errors.New("values of composite primary key " + strings.Join(headKeys.PrimaryKey, ",") + " contain ':': " + key)
+		}
+		if _, ok := rows[key]; ok {
+			return errors.New("duplicate primary key " + strings.Join(headKeys.PrimaryKey, ",") + ": " + key)
+		}
+		bytes, _ := json.Marshal(row)
+		rows[key] = string(bytes)
+		keys = append(keys, key)
+	}
+	keysBytes, _ := json.Marshal(keys)
+	key, metaKey := r.key(table.Name), r.metaKey(table.Name)
+	suffix := ":tmp:" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	tmpName, tmpMeta := "{"+key+"}"+suffix, metaKey+suffix
+	if len(rows) > 0 {
+		err = r.redis.HMSet(tmpName, rows).Err()
+	}
+	if err == nil {
+		err = r.redis.HMSet(tmpMeta, map[string]interface{}{"head": string(head), "keys": string(keysBytes)}).Err()
+	}
+	if err != nil {
+		r.redis.Del(tmpName, tmpMeta)
+		return
+	}
+	_, err = r.redis.TxPipelined(func(pipe redis.Pipeliner) error {
+		if len(rows) > 0 {
+			pipe.Rename(tmpName, key)
+		} else {
+			pipe.Del(key)
+		}
+		pipe.Rename(tmpMeta, metaKey)
+		if r.ttl > 0 {
+			pipe.Expire(key, r.ttl)
+			pipe.Expire(metaKey, r.ttl)
+		}
+		return nil
+	})
+	return
+}
+
+func (r *RedisStorage) readHash(name string) (table *Table, err error) {
+	meta, err := r.redis.HGetAll(r.metaKey(name)).Result()
+	if err != nil {
+		return
+	}
+	if len(meta) == 0 {
+		err = ErrTableNotFound
+		return
+	}
+	table = &Table{Name: name, Head: &pb.TableHead{}}
+	var keys []string
+	err = json.Unmarshal([]byte(meta["head"]), table.Head)
+	if err == nil {
+		err = json.Unmarshal([]byte(meta["keys"]), &keys)
+	}
+	if err != nil {
+		err = fmt.Errorf("%w: %v", ErrUndecodable, err)
+		return
+	}
+	rows, err := r.redis.HGetAll(r.key(name)).Result()
+	if err != nil {
+		return
+	}
+	content := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if row, ok := rows[key]; ok {
+			content = append(content, row)
+		}
+	}
+	table.Content = "[" + strings.Join(content, ",") + "]"
+	return
+}
@@ -0,0 +1,273 @@
+package rpcserver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"strings"
+)
+
+// sqlDialect is what differs between the sql databases with transactional DDL
+type sqlDialect interface {
+	quoteIdentifier(name string) string
+	quoteLiteral(literal string) string
+	// columnType returns the column definition of a type without constraints of nullability
+	columnType(ct ColumnType, field string) string
+	// encodeCell converts a decoded cell into a bind parameter
+	encodeCell(ct ColumnType, cell interface{}) interface{}
+	// placeholder returns the bind parameter of the index from 1
+	placeholder(index int) string
+	// maxParams is the max number of bind parameters in one multi row insert,
+	// rows are inserted one by one when it's 0 or a single row has more
+	maxParams() int
+	// commentSql returns the statement setting the comment of a column,
+	// it's empty if the database has no column comments
+	commentSql(tableName, field, desc string) string
+	// tableCountSql returns the query counting the tables named by its parameter
+	tableCountSql() string
+}
+
+// txSqlStorage writes tables to a sql database with transactional DDL, postgres or sqlite.
+// The table is created and filled as a temp table and swapped in within a single transaction.
+type txSqlStorage struct {
+	db      *sqlx.DB
+	dialect sqlDialect
+}
+
+func (q *txSqlStorage) WriteTable(ctx context.Context, table *Table) error {
+	return q.writeTable(ctx, table, false)
+}
+
+// DryRunTable creates, fills and indexes the temp table like WriteTable and rolls the
+// transaction back. The table isn't swapped in, so the live table is neither dropped nor locked.
+func (q *txSqlStorage) DryRunTable(ctx context.Context, table *Table) error {
+	return q.writeTable(ctx, table, true)
+}
+
+func (q *txSqlStorage) writeTable(ctx context.Context, table *Table, dryRun bool) error {
+	types, keys, err := q.parseTable(table)
+	if err != nil {
+		return err
+	}
+	return q.inTx(ctx, dryRun, func(tx *sql.Tx) (err error) {
+		tableName := table.Name
+		if dryRun {
+			tableName = tempName(table.Name)
+			err = q.fillTable(ctx, tx, table, types, keys, tableName)
+		} else {
+			err = q.buildTable(ctx, tx, table, types, keys, tableName)
+		}
+		if err == nil {
+			err = q.createIndexes(ctx, tx, tableName, keys)
+		}
+		return
+	})
+}
+
+// StageTable builds the table into its staged table,
+// the indexes are created when it's published as they are named after the table
+func (q *txSqlStorage) StageTable(ctx context.Context, table *Table) error {
+	types, keys, err := q.parseTable(table)
+	if err != nil {
+		return err
+	}
+	return q.inTx(ctx, false, func(tx *sql.Tx) error {
+		return q.buildTable(ctx, tx, table, types, keys, stagedTableName(table.Name))
+	})
+}
+
+func (q *txSqlStorage) PublishStaged(ctx context.Context, table *Table) error {
+	if err := checkIdentifier(table.Name); err != nil {
+		return err
+	}
+	keys, err := parseTableKeys(table.Head)
+	if err != nil {
+		return err
+	}
+	return q.inTx(ctx, false, func(tx *sql.Tx) (err error) {
+		stagedName := stagedTableName(table.Name)
+		var count int
+		err = tx.QueryRowContext(ctx, q.dialect.tableCountSql(), stagedName).Scan(&count)
+		if err == nil && count == 0 {
+			err = fmt.Errorf("%w: %s", ErrNothingStaged, table.Name)
+		}
+		if err == nil {
+			err = q.renameTable(ctx, tx, table.Name, stagedName)
+		}
+		if err == nil {
+			err = q.createIndexes(ctx, tx, table.Name, keys)
+		}
+		return
+	})
+}
+
+func (q *txSqlStorage) DiscardStaged(ctx context.Context, name string) (err error) {
+	if err = checkIdentifier(name); err != nil {
+		return
+	}
+	_, err = q.db.ExecContext(ctx, "DROP TABLE IF EXISTS "+q.dialect.quoteIdentifier(stagedTableName(name)))
+	return
+}
+
+func (q *txSqlStorage) DeleteTable(ctx context.Context, name string) (err error) {
+	if err = checkIdentifier(name); err != nil {
+		return
+	}
+	_, err = q.db.ExecContext(ctx, "DROP TABLE IF EXISTS "+q.dialect.quoteIdentifier(name))
+	return
+}
+
+// parseTable checks the names of the table and parses the types and keys of its head
+func (q *txSqlStorage) parseTable(table *Table) (types []ColumnType, keys tableKeys, err error) {
+	err = checkTableIdentifiers(table.Name, table.Head)
+	if err == nil {
+		types, err = parseHeadTypes(table.Head)
+	}
+	if err == nil {
+		keys, err = parseTableKeys(table.Head)
+	}
+	return
+}
+
+// inTx runs fn in a transaction, which is rolled back if fn fails or rollback is set
+func (q *txSqlStorage) inTx(ctx context.Context, rollback bool, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	err = fn(tx)
+	if err == nil && !rollback {
+		err = tx.Commit()
+	}
+	if err != nil || rollback {
+		tx.Rollback()
+	}
+	return
+}
+
+// buildTable creates and fills a temp table, then swaps it in as tableName
+func (q *txSqlStorage) buildTable(ctx context.Context, tx *sql.Tx, table *Table, types []ColumnType, keys tableKeys, tableName string) (err error) {
+	tempTableName := tempName(tableName)
+	err = q.fillTable(ctx, tx, table, types, keys, tempTableName)
+	if err == nil {
+		err = q.renameTable(ctx, tx, tableName, tempTableName)
+	}
+	return
+}
+
+// fillTable creates tableName and inserts the content of the table
+func (q *txSqlStorage) fillTable(ctx context.Context, tx *sql.Tx, table *Table, types []ColumnType, keys tableKeys, tableName string) (err error) {
+	err = q.exec(ctx, tx, q.createTableSqls(table, types, keys, tableName), nil)
+	if err == nil {
+		var stmts []string
+		var args [][]interface{}
+		if stmts, args, err = q.insertSqls(table, types, tableName); err == nil {
+			err = q.exec(ctx, tx, stmts, args)
+		}
+	}
+	return
+}
+
+// exec runs the statements with their bind parameters, args may be nil
+func (q *txSqlStorage) exec(ctx context.Context, tx *sql.Tx, stmts []string, args [][]interface{}) (err error) {
+	for index, stmt := range stmts {
+		if args == nil {
+			_, err = tx.ExecContext(ctx, stmt)
+		} else {
+			_, err = tx.ExecContext(ctx, stmt, args[index]...)
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// createTableSqls returns the statements which create tableName with the comments of the columns
+func (q *txSqlStorage) createTableSqls(table *Table, types []ColumnType, keys tableKeys, tableName string) []string {
+	d := q.dialect
+	columns := make([]string, 0, len(table.Head.Fields)+1)
+	for index, field := range table.Head.Fields {
+		columns = append(columns, d.quoteIdentifier(field)+" "+d.columnType(types[index], field)+columnConstraints(types[index], d.quoteLiteral))
+	}
+	if len(keys.PrimaryKey) > 0 {
+		columns = append(columns, "PRIMARY KEY ("+q.quoteFields(keys.PrimaryKey)+")")
+	}
+	stmts := []string{
+		"DROP TABLE IF EXISTS " + d.quoteIdentifier(tableName),
+		"CREATE TABLE " + d.quoteIdentifier(tableName) + " (" + strings.Join(columns, ", ") + ")",
+	}
+	for index, field := range table.Head.Fields {
+		if comment := d.commentSql(tableName, field, table.Head.Descs[index]); comment != "" {
+			stmts = append(stmts, comment)
+		}
+	}
+	return stmts
+}
+
+// insertSqls returns the insert statements of the content with their bind parameters,
+// rows are inserted in batches within the limit of bind parameters of the dialect
+func (q *txSqlStorage) insertSqls(table *Table, types []ColumnType, tableName string) (stmts []string, args [][]interface{}, err error) {
+	content, err := decodeContent(table.Content)
+	if err != nil || len(content) == 0 {
+		return
+	}
+	insertSql := "INSERT INTO " + q.dialect.quoteIdentifier(tableName) + " (" + q.quoteFields(table.Head.Fields) + ") VALUES "
+	batchSize := 1
+	if maxParams := q.dialect.maxParams(); maxParams > len(table.Head.Fields) {
+		batchSize = maxParams / len(table.Head.Fields)
+	}
+	for start := 0; start < len(content); start += batchSize {
+		end := start + batchSize
+		if end > len(content) {
+			end = len(content)
+		}
+		values := make([]string, 0, end-start)
+		batchArgs := make([]interface{}, 0, (end-start)*len(table.Head.Fields))
+		for _, row := range content[start:end] {
+			placeholders := make([]string, 0, len(table.Head.Fields))
+			for index, field := range table.Head.Fields {
+				batchArgs = append(batchArgs, q.dialect.encodeCell(types[index], row[field]))
+				placeholders = append(placeholders, q.dialect.placeholder(len(batchArgs)))
+			}
+			values = append(values, "("+strings.Join(placeholders, ", ")+")")
+		}
+		stmts = append(stmts, insertSql+strings.Join(values, ", "))
+		args = append(args, batchArgs)
+	}
+	return
+}
+
+// createIndexes creates the unique constraints and secondary indexes after the swap,
+// index names are unique in the whole schema so they are prefixed with the table name
+// and shortened to identifiers
+func (q *txSqlStorage) createIndexes(ctx context.Context, tx *sql.Tx, tableName string, keys tableKeys) (err error) {
+	stmts := make([]string, 0, len(keys.Uniques)+len(keys.Indexes))
+	for _, unique := range keys.Uniques {
+		stmts = append(stmts, "CREATE UNIQUE INDEX "+q.dialect.quoteIdentifier(fitIdentifier(tableName+"_"+unique.Name))+
+			" ON "+q.dialect.quoteIdentifier(tableName)+" ("+q.quoteFields(unique.Fields)+")")
+	}
+	for _, index := range keys.Indexes {
+		stmts = append(stmts, "CREATE INDEX "+q.dialect.quoteIdentifier(fitIdentifier(tableName+"_"+index.Name))+
+			" ON "+q.dialect.quoteIdentifier(tableName)+" ("+q.quoteFields(index.Fields)+")")
+	}
+	return q.exec(ctx, tx, stmts, nil)
+}
+
+func (q *txSqlStorage) quoteFields(fields []string) string {
+	quoted := make([]string, 0, len(fields))
+	for _, field := range fields {
+		quoted = append(quoted, q.dialect.quoteIdentifier(field))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// renameTable swaps the temp or staged table in, the drop and the rename run in one transaction
+func (q *txSqlStorage) renameTable(ctx context.Context, tx *sql.Tx, tableName, tmpTableName string) (err error) {
+	_, err = tx.ExecContext(ctx, "DROP TABLE IF EXISTS "+q.dialect.quoteIdentifier(tableName))
+	if err == nil {
+		_, err = tx.ExecContext(ctx, "ALTER TABLE "+q.dialect.quoteIdentifier(tmpTableName)+" RENAME TO "+q.dialect.quoteIdentifier(tableName))
+	}
+	return
+}
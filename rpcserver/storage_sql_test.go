@@ -0,0 +1,83 @@
+package rpcserver
+
+import (
+	"context"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSqliteSqls(t *testing.T) {
+	q := NewSqliteStorage(nil)
+	types, err := parseHeadTypes(testPostgresTable.Head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := parseTableKeys(testPostgresTable.Head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// sqlite has no column comments
+	stmts := q.createTableSqls(testPostgresTable, types, keys, "item_list_tmp")
+	expected := []string{
+		`DROP TABLE IF EXISTS "item_list_tmp"`,
+		`CREATE TABLE "item_list_tmp" ("sid" INTEGER NOT NULL, "type" REAL NOT NULL, ` +
+			`"kind" TEXT CHECK ("kind" IN ('a', 'b')) NOT NULL, "tags" JSON, PRIMARY KEY ("sid", "type"))`,
+	}
+	if !reflect.DeepEqual(stmts, expected) {
+		t.Errorf("create table sqls:\n%s", strings.Join(stmts, "\n"))
+	}
+	stmts, args, err := q.insertSqls(testPostgresTable, types, "item_list_tmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts) != 1 || stmts[0] != `INSERT INTO "item_list_tmp" ("sid", "type", "kind", "tags") VALUES (?, ?, ?, ?), (?, ?, ?, ?)` ||
+		len(args[0]) != 8 || args[0][3] != "[1,2]" {
+		t.Errorf("insert sqls: %v, args: %v", stmts, args)
+	}
+}
+
+func TestSqliteWideTable(t *testing.T) {
+	// a row has more columns than the bind parameters of a statement, so rows are inserted one by one
+	head := &pb.TableHead{}
+	row := make([]string, 0, sqliteMaxParams)
+	for i := 0; i <= sqliteMaxParams; i++ {
+		field := "f" + strconv.Itoa(i)
+		head.Fields = append(head.Fields, field)
+		head.Types = append(head.Types, TypeInt)
+		head.Descs = append(head.Descs, "")
+		if i > 0 {
+			row = append(row, `"`+field+`":`+strconv.Itoa(i))
+		}
+	}
+	// the first field is the primary key
+	content := `[{"f0":1,` + strings.Join(row, ",") + `},{"f0":2,` + strings.Join(row, ",") + "}]"
+	table := &Table{Name: "wide_list", Head: head, Content: content}
+	if violations := validateTable(table, nil); len(violations) > 0 {
+		t.Fatalf("violations: %v", violations)
+	}
+	types, err := parseHeadTypes(head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmts, args, err := NewSqliteStorage(nil).insertSqls(table, types, "wide_list_tmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts) != 2 || len(args[0]) != sqliteMaxParams+1 {
+		t.Errorf("insert sqls of %d columns: %d statements", len(head.Fields), len(stmts))
+	}
+	storage := testStorages(t)["sqlite"]
+	if err = storage.WriteTable(context.Background(), table); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := storage.ReadTable(context.Background(), table.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Head.Fields) != len(head.Fields) {
+		t.Errorf("stored fields: %d", len(stored.Head.Fields))
+	}
+}
@@ -0,0 +1,166 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"strings"
+)
+
+// sqliteColumnTypes are the declared sqlite column types of tableHead types,
+// arrays are declared as JSON and enums as TEXT with a check constraint
+var sqliteColumnTypes = map[string]string{
+	TypeInt:      "INTEGER",
+	TypeString:   "TEXT",
+	TypeFloat:    "REAL",
+	TypeDouble:   "REAL",
+	TypeBool:     "BOOLEAN",
+	TypeDatetime: "DATETIME",
+	TypeDate:     "DATE",
+	TypeJSON:     "JSON",
+	TypeEnum:     "TEXT",
+}
+
+// sqliteHeadTypes are the tableHead types of declared sqlite column types
+var sqliteHeadTypes = map[string]string{
+	"INTEGER":  TypeInt,
+	"TEXT":     TypeString,
+	"REAL":     TypeDouble,
+	"BOOLEAN":  TypeBool,
+	"DATETIME": TypeDatetime,
+	"DATE":     TypeDate,
+	"JSON":     TypeJSON,
+}
+
+// sqliteMaxParams is the max number of bind parameters in one statement of sqlite before 3.32,
+// sheets can have more columns than that as sqlite allows 2000 columns by default
+const sqliteMaxParams = 999
+
+// SqliteStorage writes each config table into a sqlite table of the same name.
+// DDL is transactional in sqlite, so the temp table is created, filled and
+// swapped in within a single transaction.
+type SqliteStorage struct {
+	*txSqlStorage
+}
+
+// NewSqliteStorage return a Storage backed by sqlite
+func NewSqliteStorage(db *sqlx.DB) *SqliteStorage {
+	return &SqliteStorage{&txSqlStorage{db: db, dialect: sqliteDialect{}}}
+}
+
+func (q *SqliteStorage) ReadTable(ctx context.Context, name string) (table *Table, err error) {
+	if err = checkIdentifier(name); err != nil {
+		return
+	}
+	rows, err := q.db.QueryContext(ctx, "SELECT * FROM "+sqliteQuoteIdentifier(name))
+	if err != nil && strings.HasPrefix(err.Error(), "no such table") {
+		err = ErrTableNotFound
+		return
+	}
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	res, err := scanRows(rows)
+	if err != nil {
+		return
+	}
+	head, err := q.readHead(ctx, name)
+	if err != nil {
+		return
+	}
+	err = typeRows(res, head, decodeCell)
+	if err != nil {
+		return
+	}
+	bytes, _ := json.Marshal(res)
+	table = &Table{Name: name, Head: head, Content: string(bytes)}
+	return
+}
+
+func (q *SqliteStorage) ListTables(ctx context.Context) (names []string, err error) {
+	err = q.db.SelectContext(ctx, &names, "SELECT name FROM sqlite_master WHERE type = 'table'")
+	names = userTables(names)
+	return
+}
+
+// readHead rebuilds the table head from column definitions,
+// sqlite has no column comments so the descs are left empty
+func (q *SqliteStorage) readHead(ctx context.Context, name string) (head *pb.TableHead, err error) {
+	var columns []struct {
+		Field string `db:"name"`
+		Type  string `db:"type"`
+	}
+	err = q.db.Unsafe().SelectContext(ctx, &columns, "PRAGMA table_info("+sqliteQuoteIdentifier(name)+")")
+	if err != nil {
+		return
+	}
+	head = &pb.TableHead{}
+	for _, column := range columns {
+		ty, ok := sqliteHeadTypes[column.Type]
+		if !ok {
+			ty = TypeString
+		}
+		head.Fields = append(head.Fields, column.Field)
+		head.Types = append(head.Types, ty)
+		head.Descs = append(head.Descs, "")
+	}
+	return
+}
+
+// sqliteDialect declares arrays as JSON and enums as TEXT with a check constraint
+type sqliteDialect struct{}
+
+func (sqliteDialect) quoteIdentifier(name string) string {
+	return sqliteQuoteIdentifier(name)
+}
+
+func (sqliteDialect) quoteLiteral(literal string) string {
+	return sqliteQuoteLiteral(literal)
+}
+
+func (sqliteDialect) columnType(ct ColumnType, field string) string {
+	if ct.Array {
+		return sqliteColumnTypes[TypeJSON]
+	}
+	ty := sqliteColumnTypes[ct.Base]
+	if ct.Base == TypeEnum {
+		values := make([]string, 0, len(ct.EnumValues))
+		for _, value := range ct.EnumValues {
+			values = append(values, sqliteQuoteLiteral(value))
+		}
+		ty += " CHECK (" + sqliteQuoteIdentifier(field) + " IN (" + strings.Join(values, ", ") + "))"
+	}
+	return ty
+}
+
+func (sqliteDialect) encodeCell(ct ColumnType, cell interface{}) interface{} {
+	return encodeCell(ct, cell)
+}
+
+func (sqliteDialect) placeholder(index int) string {
+	return "?"
+}
+
+func (sqliteDialect) maxParams() int {
+	return sqliteMaxParams
+}
+
+// commentSql is empty as sqlite has no column comments
+func (sqliteDialect) commentSql(tableName, field, desc string) string {
+	return ""
+}
+
+func (sqliteDialect) tableCountSql() string {
+	return "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?"
+}
+
+func sqliteQuoteIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func sqliteQuoteLiteral(literal string) string {
+	return "'" + strings.Replace(literal, "'", "''", -1) + "'"
+}
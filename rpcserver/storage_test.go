@@ -0,0 +1,205 @@
+package rpcserver
+
+import (
+	"context"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"github.com/go-redis/redis"
+	"github.com/jmoiron/sqlx"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var testTable = &Table{
+	Name: "item_list",
+	Head: &pb.TableHead{
+		Fields: []string{"sid", "type", "name"},
+		Types:  []string{"int", "int", "string"},
+		Descs:  []string{"流水ID", "类型", "名称"},
+	},
+	Content: `[{"name":"名称1","sid":1,"type":1},{"name":"名称2","sid":2,"type":1}]`,
+}
+
+// testStorages returns every storage that can run without an external server
+func testStorages(t *testing.T) map[string]Storage {
+	dir, err := ioutil.TempDir("", "e2cdatabus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	db, err := sqlx.Connect("sqlite3", filepath.Join(dir, "config.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	fileDir := filepath.Join(dir, "files")
+	if err = os.Mkdir(fileDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(mr.Close)
+	redisHash := NewRedisStorage(redis.NewClient(&redis.Options{Addr: mr.Addr(), DB: 1}))
+	redisHash.SetLayout(RedisLayoutHash)
+	return map[string]Storage{
+		"memory":     NewMemoryStorage(),
+		"sqlite":     NewSqliteStorage(db),
+		"file":       NewFileStorage(fileDir),
+		"redis":      NewRedisStorage(redis.NewClient(&redis.Options{Addr: mr.Addr()})),
+		"redis_hash": redisHash,
+	}
+}
+
+func TestStorage(t *testing.T) {
+	ctx := context.TODO()
+	for name, storage := range testStorages(t) {
+		t.Run(name, func(t *testing.T) {
+			if _, err := storage.ReadTable(ctx, testTable.Name); err != ErrTableNotFound {
+				t.Fatalf("read missing table, err: %v", err)
+			}
+			if err := storage.WriteTable(ctx, testTable); err != nil {
+				t.Fatal(err)
+			}
+			// write twice to go through the swap of an existing table
+			if err := storage.WriteTable(ctx, testTable); err != nil {
+				t.Fatal(err)
+			}
+			table, err := storage.ReadTable(ctx, testTable.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if table.Content != testTable.Content {
+				t.Errorf("content is diffrent, %v", table.Content)
+			}
+			// the string layout of redis doesn't keep the head
+			if name != "redis" && len(table.Head.GetFields()) != len(testTable.Head.Fields) {
+				t.Errorf("head is diffrent, %v", table.Head)
+			}
+			names, err := storage.ListTables(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(names) != 1 || names[0] != testTable.Name {
+				t.Errorf("ListTables: %v", names)
+			}
+			if err = storage.DeleteTable(ctx, testTable.Name); err != nil {
+				t.Fatal(err)
+			}
+			if _, err = storage.ReadTable(ctx, testTable.Name); err != ErrTableNotFound {
+				t.Errorf("read deleted table, err: %v", err)
+			}
+		})
+	}
+}
+
+func TestRedisHashLayout(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	storage := NewRedisStorage(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	storage.SetLayout(RedisLayoutHash)
+	if err = storage.WriteTable(context.TODO(), testTable); err != nil {
+		t.Fatal(err)
+	}
+	row := mr.HGet(testTable.Name, "2")
+	if row != `{"name":"名称2","sid":2,"type":1}` {
+		t.Errorf("row of primary key 2: %v", row)
+	}
+	if keys := mr.Keys(); len(keys) != 3 {
+		t.Errorf("temp keys are left: %v", keys)
+	}
+	dup := *testTable
+	dup.Content = `[{"name":"名称1","sid":1,"type":1},{"name":"名称2","sid":1,"type":1}]`
+	if err = storage.WriteTable(context.TODO(), &dup); err == nil {
+		t.Errorf("duplicate primary key is written")
+	}
+}
+
+func TestRedisConf(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	for _, layout := range []RedisLayout{RedisLayoutString, RedisLayoutHash} {
+		client, err := NewRedisClient(RedisConf{Addrs: []string{mr.Addr()}, DB: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		storage := NewRedisStorage(client)
+		storage.SetLayout(layout)
+		storage.SetKeyPrefix("config:")
+		storage.SetTTL(time.Hour)
+		if err = storage.WriteTable(context.TODO(), testTable); err != nil {
+			t.Fatal(err)
+		}
+		key := "config:" + testTable.Name
+		if !mr.DB(2).Exists(key) || mr.Exists(key) {
+			t.Errorf("layout %d, %s is not written to db 2", layout, key)
+		}
+		if ttl := mr.DB(2).TTL(key); ttl != time.Hour {
+			t.Errorf("layout %d, ttl of %s: %v", layout, key, ttl)
+		}
+		names, _ := storage.ListTables(context.TODO())
+		if len(names) != 1 || names[0] != testTable.Name {
+			t.Errorf("layout %d, ListTables: %v", layout, names)
+		}
+		// the expired table is not listed anymore
+		mr.FastForward(time.Hour)
+		names, _ = storage.ListTables(context.TODO())
+		if len(names) != 0 || mr.DB(2).Exists("config:"+redisTablesKey) {
+			t.Errorf("layout %d, ListTables after the ttl: %v", layout, names)
+		}
+		mr.FlushAll()
+	}
+}
+
+func TestSqlInjection(t *testing.T) {
+	storage := testStorages(t)["sqlite"]
+	bad := *testTable
+	bad.Name = "item_list; DROP TABLE user"
+	if err := storage.WriteTable(context.TODO(), &bad); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("write table %q, err: %v", bad.Name, err)
+	}
+	if _, err := storage.ReadTable(context.TODO(), bad.Name); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("read table %q, err: %v", bad.Name, err)
+	}
+	quoted := *testTable
+	quoted.Content = `[{"name":"it's 'quoted'","sid":1,"type":1}]`
+	if err := storage.WriteTable(context.TODO(), &quoted); err != nil {
+		t.Fatal(err)
+	}
+	if table, _ := storage.ReadTable(context.TODO(), quoted.Name); table.Content != quoted.Content {
+		t.Errorf("content is diffrent, %v", table.Content)
+	}
+	if literal := mysqlQuoteLiteral(`it's \'`); literal != `'it''s \\'''` {
+		t.Errorf("mysqlQuoteLiteral: %s", literal)
+	}
+}
+
+func TestFileStorageNames(t *testing.T) {
+	storage := testStorages(t)["file"]
+	ctx := context.TODO()
+	if err := storage.WriteTable(ctx, testTable); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"", "..", "../item_list", testTable.Name + ".schema"} {
+		bad := *testTable
+		bad.Name = name
+		if err := storage.WriteTable(ctx, &bad); err == nil {
+			t.Errorf("table %q is written", name)
+		}
+	}
+	if table, err := storage.ReadTable(ctx, testTable.Name); err != nil || len(table.Head.GetFields()) != 3 {
+		t.Errorf("schema sidecar is overwritten: %v, %v", table, err)
+	}
+}
@@ -0,0 +1,286 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// column types of tableHead, array types are written as "int[]", "string[]" and so on,
+// enum types list their values like "enum(gold,silver,copper)"
+const (
+	TypeInt      = "int"
+	TypeString   = "string"
+	TypeFloat    = "float"
+	TypeDouble   = "double"
+	TypeBool     = "bool"
+	TypeDatetime = "datetime"
+	TypeDate     = "date"
+	TypeJSON     = "json"
+	TypeEnum     = "enum"
+
+	arraySuffix    = "[]"
+	datetimeLayout = "2006-01-02 15:04:05"
+	dateLayout     = "2006-01-02"
+)
+
+// arrayBaseTypes are the types which can be used as elements of an array
+var arrayBaseTypes = map[string]bool{
+	TypeInt: true, TypeString: true, TypeFloat: true, TypeDouble: true, TypeBool: true,
+}
+
+// ColumnType is a parsed type of tableHead.Types
+type ColumnType struct {
+	Base  string
+	Array bool
+	// EnumValues are the allowed values of an enum type
+	EnumValues []string
+	// Nullable and Default come from tableHead.Nullable and tableHead.Defaults,
+	// Default is a cell like those decoded by decodeContent, nil if there is no default
+	Nullable bool
+	Default  interface{}
+}
+
+// ParseColumnType parses a type of tableHead.Types, e.g. "int", "string[]", "enum(a,b)"
+func ParseColumnType(ty string) (ct ColumnType, err error) {
+	ty = strings.TrimSpace(ty)
+	if strings.HasSuffix(ty, arraySuffix) {
+		ct, err = ParseColumnType(strings.TrimSuffix(ty, arraySuffix))
+		if err == nil && !arrayBaseTypes[ct.Base] {
+			err = fmt.Errorf("unsupported array type %q", ty)
+		}
+		ct.Array = true
+		return
+	}
+	if strings.HasPrefix(ty, TypeEnum+"(") && strings.HasSuffix(ty, ")") {
+		values := strings.Split(ty[len(TypeEnum)+1:len(ty)-1], ",")
+		ct = ColumnType{Base: TypeEnum}
+		for _, value := range values {
+			if value = strings.TrimSpace(value); value == "" {
+				return ct, fmt.Errorf("empty enum value in %q", ty)
+			}
+			ct.EnumValues = append(ct.EnumValues, value)
+		}
+		return
+	}
+	switch ty {
+	case TypeInt, TypeString, TypeFloat, TypeDouble, TypeBool, TypeDatetime, TypeDate, TypeJSON:
+		ct = ColumnType{Base: ty}
+	default:
+		err = fmt.Errorf("unknown column type %q", ty)
+	}
+	return
+}
+
+func (c ColumnType) String() string {
+	ty := c.Base
+	if c.Base == TypeEnum {
+		ty += "(" + strings.Join(c.EnumValues, ",") + ")"
+	}
+	if c.Array {
+		ty += arraySuffix
+	}
+	return ty
+}
+
+// parseHeadTypes parses all the types of the head with their nullability and defaults
+func parseHeadTypes(head *pb.TableHead) ([]ColumnType, error) {
+	fields := make(map[string]bool, len(head.Fields))
+	for _, field := range head.Fields {
+		fields[field] = true
+	}
+	nullable := make(map[string]bool, len(head.Nullable))
+	for _, field := range head.Nullable {
+		if !fields[field] {
+			return nil, fmt.Errorf("nullable: unknown field %q", field)
+		}
+		nullable[field] = true
+	}
+	for field := range head.Defaults {
+		if !fields[field] {
+			return nil, fmt.Errorf("defaults: unknown field %q", field)
+		}
+	}
+	types := make([]ColumnType, 0, len(head.Types))
+	for index, ty := range head.Types {
+		field := head.Fields[index]
+		ct, err := ParseColumnType(ty)
+		if err == nil {
+			if text, ok := head.Defaults[field]; ok {
+				ct.Default, err = parseDefault(ct, text)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field, err)
+		}
+		ct.Nullable = nullable[field]
+		types = append(types, ct)
+	}
+	return types, nil
+}
+
+// parseDefault converts the default text of a column into a cell of the column type
+func parseDefault(ct ColumnType, text string) (cell interface{}, err error) {
+	if ct.Array || ct.Base == TypeJSON {
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.UseNumber()
+		err = decoder.Decode(&cell)
+		if err == nil && ct.Array {
+			if _, ok := cell.([]interface{}); !ok {
+				err = errors.New("default is not an array: " + text)
+			}
+		}
+		return
+	}
+	switch ct.Base {
+	case TypeInt:
+		_, err = strconv.ParseInt(text, 10, 64)
+		cell = json.Number(text)
+	case TypeFloat, TypeDouble:
+		_, err = strconv.ParseFloat(text, 64)
+		cell = json.Number(text)
+	case TypeBool:
+		cell, err = parseBool(text)
+	case TypeDatetime:
+		_, err = time.Parse(datetimeLayout, text)
+		cell = text
+	case TypeDate:
+		_, err = time.Parse(dateLayout, text)
+		cell = text
+	case TypeEnum:
+		cell = text
+		err = fmt.Errorf("default %q is not a value of the enum", text)
+		for _, value := range ct.EnumValues {
+			if value == text {
+				err = nil
+			}
+		}
+	default:
+		cell = text
+	}
+	if err != nil {
+		cell = nil
+	}
+	return
+}
+
+// defaultLiteral formats the default of a scalar column as text for a DEFAULT clause,
+// ok is false if the column has no default or it's a json or array column
+func (c ColumnType) defaultLiteral() (literal string, ok bool) {
+	if c.Default == nil || c.Array || c.Base == TypeJSON {
+		return "", false
+	}
+	if b, isBool := c.Default.(bool); isBool {
+		if b {
+			return "1", true
+		}
+		return "0", true
+	}
+	return fmt.Sprint(c.Default), true
+}
+
+// columnConstraints returns the NOT NULL and DEFAULT clauses of a column definition
+func columnConstraints(ct ColumnType, quoteLiteral func(string) string) string {
+	constraints := ""
+	if !ct.Nullable {
+		constraints += " NOT NULL"
+	}
+	if literal, ok := ct.defaultLiteral(); ok {
+		constraints += " DEFAULT " + quoteLiteral(literal)
+	}
+	return constraints
+}
+
+// encodeCell converts a cell decoded by decodeContent into a bind parameter of the column type,
+// json and array cells are encoded as json text. Missing cells are replaced with the default,
+// and are NULL in nullable columns without a default.
+func encodeCell(ct ColumnType, cell interface{}) interface{} {
+	if cell == nil {
+		cell = ct.Default
+	}
+	if cell == nil {
+		if ct.Nullable {
+			return nil
+		}
+		return cellValue(cell)
+	}
+	if ct.Array || ct.Base == TypeJSON {
+		bytes, _ := json.Marshal(cell)
+		return string(bytes)
+	}
+	if ct.Base == TypeBool {
+		if b, err := parseBool(cell); err == nil {
+			return b
+		}
+	}
+	return cellValue(cell)
+}
+
+// decodeCell converts a value scanned from a sql storage into a json value of the column type
+func decodeCell(ct ColumnType, raw interface{}) (interface{}, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	if t, ok := raw.(time.Time); ok {
+		if ct.Base == TypeDate {
+			return t.Format(dateLayout), nil
+		}
+		return t.Format(datetimeLayout), nil
+	}
+	if ct.Array || ct.Base == TypeJSON {
+		text := rawString(raw)
+		if !json.Valid([]byte(text)) {
+			return nil, fmt.Errorf("invalid json %q", text)
+		}
+		return json.RawMessage(text), nil
+	}
+	switch ct.Base {
+	case TypeInt:
+		if i, ok := raw.(int64); ok {
+			return i, nil
+		}
+		return strconv.ParseInt(rawString(raw), 10, 64)
+	case TypeFloat, TypeDouble:
+		if f, ok := raw.(float64); ok {
+			return f, nil
+		}
+		return strconv.ParseFloat(rawString(raw), 64)
+	case TypeBool:
+		return parseBool(raw)
+	default:
+		return rawString(raw), nil
+	}
+}
+
+// rawString formats a scanned value as string
+func rawString(raw interface{}) string {
+	switch val := raw.(type) {
+	case []byte:
+		return string(val)
+	case string:
+		return val
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// parseBool accepts true/false, 1/0 and their string forms
+func parseBool(val interface{}) (bool, error) {
+	switch v := val.(type) {
+	case bool:
+		return v, nil
+	case int64:
+		return v != 0, nil
+	case json.Number:
+		return strconv.ParseBool(v.String())
+	case []byte:
+		return strconv.ParseBool(string(v))
+	case string:
+		return strconv.ParseBool(v)
+	}
+	return false, errors.New("invalid bool " + fmt.Sprint(val))
+}
@@ -0,0 +1,156 @@
+package rpcserver
+
+import (
+	"context"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"reflect"
+	"testing"
+)
+
+func TestParseColumnType(t *testing.T) {
+	cases := []struct {
+		ty  string
+		ct  ColumnType
+		err bool
+	}{
+		{"int", ColumnType{Base: TypeInt}, false},
+		{"double", ColumnType{Base: TypeDouble}, false},
+		{"string[]", ColumnType{Base: TypeString, Array: true}, false},
+		{"enum(gold, silver)", ColumnType{Base: TypeEnum, EnumValues: []string{"gold", "silver"}}, false},
+		{"enum(gold,)", ColumnType{}, true},
+		{"json[]", ColumnType{}, true},
+		{"uint", ColumnType{}, true},
+	}
+	for _, c := range cases {
+		ct, err := ParseColumnType(c.ty)
+		if c.err {
+			if err == nil {
+				t.Errorf("ParseColumnType(%s) should fail", c.ty)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(ct, c.ct) {
+			t.Errorf("ParseColumnType(%s): %+v, %v", c.ty, ct, err)
+		}
+	}
+}
+
+func TestTypedRoundTrip(t *testing.T) {
+	table := &Table{
+		Name: "typed_list",
+		Head: &pb.TableHead{
+			Fields: []string{"id", "rate", "open", "start", "day", "extra", "grade", "rewards", "tags"},
+			Types:  []string{"int", "double", "bool", "datetime", "date", "json", "enum(gold,silver)", "int[]", "string[]"},
+			Descs:  []string{"", "", "", "", "", "", "", "", ""},
+		},
+		Content: `[{"day":"2021-01-02","extra":{"a":[1,2]},"grade":"gold","id":1,"open":true,"rate":0.25,` +
+			`"rewards":[1001,1002],"start":"2021-01-02 15:04:05","tags":["new","hot"]}]`,
+	}
+	storage := testStorages(t)["sqlite"]
+	if err := storage.WriteTable(context.TODO(), table); err != nil {
+		t.Fatal(err)
+	}
+	read, err := storage.ReadTable(context.TODO(), table.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Content != table.Content {
+		t.Errorf("content is diffrent, %v", read.Content)
+	}
+	bad := *table
+	bad.Content = `[{"day":"2021-01-02","extra":{},"grade":"bronze","id":1,"open":true,"rate":0.25,` +
+		`"rewards":[],"start":"2021-01-02 15:04:05","tags":[]}]`
+	if err = storage.WriteTable(context.TODO(), &bad); err == nil {
+		t.Errorf("value out of enum is written")
+	}
+}
+
+func TestDecodeCell(t *testing.T) {
+	// values are scanned from mysql as []byte
+	cases := []struct {
+		ty  string
+		raw interface{}
+		val interface{}
+	}{
+		{"string", []byte("007"), "007"},
+		{"string", nil, nil},
+		{"int", []byte("7"), int64(7)},
+		{"int", nil, nil},
+		{"double", []byte("0.5"), 0.5},
+		{"bool", []byte("1"), true},
+		{"date", []byte("2021-01-02"), "2021-01-02"},
+	}
+	for _, c := range cases {
+		ct, _ := ParseColumnType(c.ty)
+		val, err := decodeCell(ct, c.raw)
+		if err != nil || val != c.val {
+			t.Errorf("decodeCell(%s, %v): %v, %v", c.ty, c.raw, val, err)
+		}
+	}
+}
+
+func TestNullableAndDefaults(t *testing.T) {
+	table := &Table{
+		Name: "item_list",
+		Head: &pb.TableHead{
+			Fields:   []string{"id", "note", "count", "open", "grade", "tags"},
+			Types:    []string{"int", "int", "int", "bool", "enum(gold,silver)", "int[]"},
+			Descs:    []string{"", "", "", "", "", ""},
+			Nullable: []string{"note", "count"},
+			Defaults: map[string]string{"count": "1", "open": "true", "grade": "silver", "tags": "[1,2]"},
+		},
+		Content: `[{"id":1},{"count":null,"id":2,"note":3,"open":false}]`,
+	}
+	storage := testStorages(t)["sqlite"]
+	if err := storage.WriteTable(context.TODO(), table); err != nil {
+		t.Fatal(err)
+	}
+	read, err := storage.ReadTable(context.TODO(), table.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"count":1,"grade":"silver","id":1,"note":null,"open":true,"tags":[1,2]},` +
+		`{"count":1,"grade":"silver","id":2,"note":3,"open":false,"tags":[1,2]}]`
+	if read.Content != expected {
+		t.Errorf("content is diffrent, %v", read.Content)
+	}
+	for _, head := range []*pb.TableHead{
+		{Fields: []string{"id", "count"}, Types: []string{"int", "int"}, Defaults: map[string]string{"count": "one"}},
+		{Fields: []string{"id", "grade"}, Types: []string{"int", "enum(a,b)"}, Defaults: map[string]string{"grade": "c"}},
+		{Fields: []string{"id", "tags"}, Types: []string{"int", "int[]"}, Defaults: map[string]string{"tags": "1"}},
+		{Fields: []string{"id"}, Types: []string{"int"}, Nullable: []string{"note"}},
+	} {
+		if _, err = parseHeadTypes(head); err == nil {
+			t.Errorf("invalid head is accepted: %v", head)
+		}
+	}
+	if _, err = parseTableKeys(&pb.TableHead{Fields: []string{"id"}, Nullable: []string{"id"}}); err == nil {
+		t.Error("nullable primary key is accepted")
+	}
+}
+
+func TestFloatColumns(t *testing.T) {
+	ct, err := ParseColumnType(TypeFloat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// json numbers are doubles, single precision columns would round 0.123456789
+	if ty := NewMysqlStorage(nil).columnType(ct); ty != "double" {
+		t.Errorf("mysql column type of float: %s", ty)
+	}
+	if ty := (postgresDialect{}).columnType(ct, "rate"); ty != "double precision" {
+		t.Errorf("postgres column type of float: %s", ty)
+	}
+	table := &Table{
+		Name:    "rate_list",
+		Head:    &pb.TableHead{Fields: []string{"id", "rate"}, Types: []string{"int", "float"}, Descs: []string{"", ""}},
+		Content: `[{"id":1,"rate":0.123456789}]`,
+	}
+	storage := testStorages(t)["sqlite"]
+	if err = storage.WriteTable(context.TODO(), table); err != nil {
+		t.Fatal(err)
+	}
+	if read, _ := storage.ReadTable(context.TODO(), table.Name); read.Content != table.Content {
+		t.Errorf("content is different, %v", read.Content)
+	}
+}
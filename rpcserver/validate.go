@@ -0,0 +1,233 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validateTable checks the table against its head and the rules before anything is written,
+// serverRules are the server side rules of the table, it returns all the violations found,
+// or nil if the table is valid. The content is only checked if the head itself is consistent.
+func validateTable(table *Table, serverRules map[string]*pb.ColumnRule) []*pb.Violation {
+	types, keys, violations := validateHead(table.Head)
+	if len(violations) > 0 {
+		return violations
+	}
+	rules, err := compileRules(table.Head, types, serverRules)
+	if err != nil {
+		return []*pb.Violation{{Reason: err.Error()}}
+	}
+	content, err := decodeContent(table.Content)
+	if err != nil {
+		return []*pb.Violation{{Reason: "invalid content: " + err.Error()}}
+	}
+	fields := make(map[string]int, len(table.Head.Fields))
+	for index, field := range table.Head.Fields {
+		fields[field] = index
+	}
+	for index, row := range content {
+		violations = append(violations, validateRow(index+1, row, table.Head.Fields, fields, types, rules)...)
+	}
+	violations = append(violations, validateKey(content, keys.PrimaryKey, "primary key")...)
+	for _, unique := range keys.Uniques {
+		violations = append(violations, validateKey(content, unique.Fields, "unique key "+unique.Name)...)
+	}
+	return violations
+}
+
+// validateHead checks the fields, types, descs, keys and references of the head are consistent
+func validateHead(head *pb.TableHead) (types []ColumnType, keys tableKeys, violations []*pb.Violation) {
+	if len(head.GetFields()) == 0 {
+		violations = append(violations, &pb.Violation{Reason: "empty table head"})
+		return
+	}
+	if len(head.Types) != len(head.Fields) || len(head.Descs) != len(head.Fields) {
+		violations = append(violations, &pb.Violation{Reason: fmt.Sprintf(
+			"head has %d fields, %d types and %d descs", len(head.Fields), len(head.Types), len(head.Descs))})
+		return
+	}
+	seen := make(map[string]bool, len(head.Fields))
+	for _, field := range head.Fields {
+		if field == "" {
+			violations = append(violations, &pb.Violation{Reason: "empty field name"})
+		} else if seen[field] {
+			violations = append(violations, &pb.Violation{Column: field, Reason: "duplicate field"})
+		}
+		seen[field] = true
+	}
+	if len(violations) > 0 {
+		return
+	}
+	types, err := parseHeadTypes(head)
+	if err == nil {
+		keys, err = parseTableKeys(head)
+	}
+	if err == nil {
+		err = checkReferences(head, types)
+	}
+	if err != nil {
+		violations = append(violations, &pb.Violation{Reason: err.Error()})
+	}
+	return
+}
+
+// validateRow checks the cells of a row conform to the types and rules, and there is no unknown field
+func validateRow(row int, cells map[string]interface{}, fieldList []string, fields map[string]int,
+	types []ColumnType, rules map[string][]*columnRule) (violations []*pb.Violation) {
+	unknown := make([]string, 0)
+	for field := range cells {
+		if _, ok := fields[field]; !ok {
+			unknown = append(unknown, field)
+		}
+	}
+	sort.Strings(unknown)
+	for _, field := range unknown {
+		violations = append(violations, &pb.Violation{Row: int32(row), Column: field, Reason: "unknown field"})
+	}
+	for index, field := range fieldList {
+		ct := types[index]
+		cell := cells[field]
+		var err error
+		if cell == nil {
+			// missing strings are written as "" for compatibility with older uploads
+			if !ct.Nullable && ct.Default == nil && (ct.Base != TypeString || ct.Array) {
+				err = errors.New("missing value")
+			}
+		} else {
+			err = checkCell(ct, cell)
+		}
+		for _, rule := range rules[field] {
+			if err == nil {
+				err = rule.check(ct, cell)
+			}
+		}
+		if err != nil {
+			violations = append(violations, &pb.Violation{Row: int32(row), Column: field, Reason: err.Error()})
+		}
+	}
+	return
+}
+
+// validateKey finds rows with the same values of the key fields,
+// rows with a null in any of the fields are ignored like sql does
+func validateKey(content []map[string]interface{}, keyFields []string, key string) (violations []*pb.Violation) {
+	if len(keyFields) == 0 {
+		return
+	}
+	rows := make(map[string]int, len(content))
+	for index, cells := range content {
+		hasNull := false
+		for _, field := range keyFields {
+			hasNull = hasNull || cells[field] == nil
+		}
+		if hasNull {
+			continue
+		}
+		identity := keyIdentity(cells, keyFields)
+		if first, ok := rows[identity]; ok {
+			violations = append(violations, &pb.Violation{
+				Row:    int32(index + 1),
+				Column: strings.Join(keyFields, ","),
+				Reason: fmt.Sprintf("duplicate %s %s of row %d", key, rowKey(cells, keyFields), first),
+			})
+			continue
+		}
+		rows[identity] = index + 1
+	}
+	return
+}
+
+// checkCell checks a cell decoded by decodeContent is a value of the column type
+func checkCell(ct ColumnType, cell interface{}) error {
+	if ct.Array {
+		elems, ok := cell.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s is not an array", cellText(cell))
+		}
+		elemType := ColumnType{Base: ct.Base}
+		for index, elem := range elems {
+			if elem == nil {
+				return fmt.Errorf("null element %d", index)
+			}
+			if err := checkCell(elemType, elem); err != nil {
+				return fmt.Errorf("element %d: %w", index, err)
+			}
+		}
+		return nil
+	}
+	var err error
+	switch ct.Base {
+	case TypeInt:
+		number, ok := cell.(json.Number)
+		if ok {
+			_, err = strconv.ParseInt(number.String(), 10, 64)
+		}
+		if !ok || err != nil {
+			return fmt.Errorf("%s is not an int", cellText(cell))
+		}
+	case TypeFloat, TypeDouble:
+		if _, ok := cell.(json.Number); !ok {
+			return fmt.Errorf("%s is not a number", cellText(cell))
+		}
+	case TypeBool:
+		if _, err = parseBool(cell); err != nil {
+			return fmt.Errorf("%s is not a bool", cellText(cell))
+		}
+	case TypeString:
+		switch cell.(type) {
+		case string, json.Number:
+		default:
+			return fmt.Errorf("%s is not a string", cellText(cell))
+		}
+	case TypeDatetime, TypeDate:
+		layout := datetimeLayout
+		if ct.Base == TypeDate {
+			layout = dateLayout
+		}
+		text, ok := cell.(string)
+		if ok {
+			_, err = time.Parse(layout, text)
+		}
+		if !ok || err != nil {
+			return fmt.Errorf("%s is not a %s like %s", cellText(cell), ct.Base, layout)
+		}
+	case TypeEnum:
+		text, _ := cell.(string)
+		for _, value := range ct.EnumValues {
+			if text == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s is not one of %s", cellText(cell), strings.Join(ct.EnumValues, ","))
+	}
+	return nil
+}
+
+// cellText formats a cell as json for violation reasons
+func cellText(cell interface{}) string {
+	bytes, _ := json.Marshal(cell)
+	return string(bytes)
+}
+
+// violationsMessage summarizes the violations for UpdateConfigResp.ErrMsg
+func violationsMessage(violations []*pb.Violation) string {
+	v := violations[0]
+	msg := "invalid table: "
+	if v.Row > 0 {
+		msg += fmt.Sprintf("row %d ", v.Row)
+	}
+	if v.Column != "" {
+		msg += "column " + v.Column + " "
+	}
+	msg += v.Reason
+	if len(violations) > 1 {
+		msg += fmt.Sprintf(" and %d more", len(violations)-1)
+	}
+	return msg
+}
@@ -0,0 +1,151 @@
+package rpcserver
+
+import (
+	"context"
+	pb "github.com/fandypeng/e2cdatabus/proto"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestValidateTable(t *testing.T) {
+	head := &pb.TableHead{
+		Fields:   []string{"id", "name", "rate", "open", "day", "grade", "tags", "note"},
+		Types:    []string{"int", "string", "double", "bool", "date", "enum(gold,silver)", "int[]", "int"},
+		Descs:    []string{"", "", "", "", "", "", "", ""},
+		Nullable: []string{"note"},
+		Uniques:  []*pb.TableIndex{{Fields: []string{"name"}}},
+	}
+	cases := []struct {
+		content    string
+		violations []*pb.Violation
+	}{
+		{`[{"id":1,"rate":0.5,"open":true,"day":"2021-01-02","grade":"gold","tags":[1]}]`, nil},
+		{`{"id":1}`, []*pb.Violation{{Reason: "invalid content: json: cannot unmarshal object into Go value of type []map[string]interface {}"}}},
+		{`[{"id":1.5,"rate":"a","open":"yes","day":"2021/01/02","grade":"bronze","tags":[1,"a"],"note":{},"level":1}]`, []*pb.Violation{
+			{Row: 1, Column: "level", Reason: "unknown field"},
+			{Row: 1, Column: "id", Reason: "1.5 is not an int"},
+			{Row: 1, Column: "rate", Reason: `"a" is not a number`},
+			{Row: 1, Column: "open", Reason: `"yes" is not a bool`},
+			{Row: 1, Column: "day", Reason: `"2021/01/02" is not a date like 2006-01-02`},
+			{Row: 1, Column: "grade", Reason: `"bronze" is not one of gold,silver`},
+			{Row: 1, Column: "tags", Reason: `element 1: "a" is not an int`},
+			{Row: 1, Column: "note", Reason: "{} is not an int"},
+		}},
+		{`[{"id":1,"name":"a","rate":0,"open":1,"day":"2021-01-02","grade":"gold","tags":[]},{"id":1,"name":"a"}]`, []*pb.Violation{
+			{Row: 2, Column: "rate", Reason: "missing value"},
+			{Row: 2, Column: "open", Reason: "missing value"},
+			{Row: 2, Column: "day", Reason: "missing value"},
+			{Row: 2, Column: "grade", Reason: "missing value"},
+			{Row: 2, Column: "tags", Reason: "missing value"},
+			{Row: 2, Column: "id", Reason: "duplicate primary key 1 of row 1"},
+			{Row: 2, Column: "name", Reason: "duplicate unique key uk_name a of row 1"},
+		}},
+	}
+	for _, c := range cases {
+		violations := validateTable(&Table{Name: "item_list", Head: head, Content: c.content}, nil)
+		if !reflect.DeepEqual(violations, c.violations) {
+			t.Errorf("validateTable(%s): %v", c.content, violations)
+		}
+	}
+	bad := []*pb.TableHead{
+		nil,
+		{Fields: []string{"id", "name"}, Types: []string{"int"}, Descs: []string{"", ""}},
+		{Fields: []string{"id", "id"}, Types: []string{"int", "int"}, Descs: []string{"", ""}},
+		{Fields: []string{"id"}, Types: []string{"uint"}, Descs: []string{""}},
+	}
+	for _, head := range bad {
+		if violations := validateTable(&Table{Name: "item_list", Head: head, Content: `[]`}, nil); len(violations) != 1 || violations[0].Row != 0 {
+			t.Errorf("head %v: %v", head, violations)
+		}
+	}
+}
+
+func TestUpdateConfigViolations(t *testing.T) {
+	storage := NewMemoryStorage()
+	s := NewService()
+	s.SetStorage(storage)
+	req := *testUpdateReq
+	req.Content = `[{"name":"名称1","sid":1,"type":"a"},{"name":"名称2","sid":1,"type":1}]`
+	resp, err := s.UpdateConfig(context.TODO(), &req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != StatusInvalid || len(resp.Violations) != 2 ||
+		resp.ErrMsg != `invalid table: row 1 column type "a" is not an int and 1 more` {
+		t.Errorf("UpdateConfig resp: %v", resp)
+	}
+	if _, err = storage.ReadTable(context.TODO(), req.Name); err != ErrTableNotFound {
+		t.Errorf("invalid table is written, err: %v", err)
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	head := &pb.TableHead{
+		Fields: []string{"id", "name", "price", "tags", "code"},
+		Types:  []string{"int", "string", "double", "int[]", "string"},
+		Descs:  []string{"", "", "", "", ""},
+		Rules: map[string]*pb.ColumnRule{
+			"name":  {NonEmpty: true, MaxLength: 4},
+			"price": {Min: "0", Max: "99.5"},
+			"tags":  {MaxLength: 2, OneOf: []string{"1", "2", "3"}},
+		},
+	}
+	serverRules := map[string]*pb.ColumnRule{
+		"code": {Pattern: `[A-Z]{2}\d+`},
+		"id":   {Min: "1"},
+	}
+	content := `[{"code":"AB1","id":1,"name":"名称","price":0,"tags":[1,3]},` +
+		`{"code":"ab1","id":0,"name":"","price":100,"tags":[1,2,3]},` +
+		`{"code":"AB1x","id":2,"name":"12345","price":-1,"tags":[4]}]`
+	violations := validateTable(&Table{Name: "item_list", Head: head, Content: content}, serverRules)
+	expected := []*pb.Violation{
+		{Row: 2, Column: "id", Reason: "0 is less than min 1"},
+		{Row: 2, Column: "name", Reason: "empty value"},
+		{Row: 2, Column: "price", Reason: "100 is greater than max 99.5"},
+		{Row: 2, Column: "tags", Reason: "3 elements are more than max length 2"},
+		{Row: 2, Column: "code", Reason: `"ab1" doesn't match pattern [A-Z]{2}\d+`},
+		{Row: 3, Column: "name", Reason: "5 characters are more than max length 4"},
+		{Row: 3, Column: "price", Reason: "-1 is less than min 0"},
+		{Row: 3, Column: "tags", Reason: "element 0: 4 is not one of 1,2,3"},
+		{Row: 3, Column: "code", Reason: `"AB1x" doesn't match pattern [A-Z]{2}\d+`},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("violations: %v", violations)
+	}
+	for _, rule := range []*pb.ColumnRule{
+		{Min: "a"},
+		{Min: "2", Max: "1"},
+		{Pattern: "("},
+		{MaxLength: -1},
+	} {
+		if _, err := compileRule(ColumnType{Base: TypeInt}, rule); err == nil {
+			t.Errorf("invalid rule is accepted: %v", rule)
+		}
+	}
+	if _, err := compileRule(ColumnType{Base: TypeString}, &pb.ColumnRule{Min: "1"}); err == nil {
+		t.Error("range of a string column is accepted")
+	}
+	if violations = validateTable(&Table{Name: "item_list", Head: head, Content: `[]`},
+		map[string]*pb.ColumnRule{"level": {NonEmpty: true}}); len(violations) != 1 {
+		t.Errorf("rule of unknown field: %v", violations)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	file, err := ioutil.TempFile("", "rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString(`{"item_list": {"name": {"nonEmpty": true, "maxLength": 32}}}`)
+	file.Close()
+	s := NewService()
+	if err = s.SetRulesFile(file.Name()); err != nil {
+		t.Fatal(err)
+	}
+	if rule := s.rules["item_list"]["name"]; !rule.GetNonEmpty() || rule.GetMaxLength() != 32 {
+		t.Errorf("rules: %v", s.rules)
+	}
+}